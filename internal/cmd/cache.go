@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/luuuc/council/internal/config"
+	"github.com/luuuc/council/internal/review"
+	"github.com/spf13/cobra"
+)
+
+var cacheStatsJSON bool
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheStatsCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+
+	cacheStatsCmd.Flags().BoolVar(&cacheStatsJSON, "json", false, "Output as JSON")
+}
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the review result cache",
+	Long: `Manage cached review results in .council/cache/.
+
+council review stores each result keyed by the submission, the expert
+personas, the backend and model, and the prompt version. An identical
+rerun is served from the cache without calling the AI backend.
+
+Examples:
+  council cache stats     # Show entry count and size
+  council cache clear     # Remove all cached results`,
+}
+
+var cacheStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show review cache statistics",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stats, err := review.NewCache(config.Path(config.CacheDir)).Stats()
+		if err != nil {
+			return err
+		}
+
+		if cacheStatsJSON {
+			data, err := json.MarshalIndent(stats, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		fmt.Printf("Cache: %s\n", config.Path(config.CacheDir))
+		fmt.Printf("Entries: %d\n", stats.Entries)
+		fmt.Printf("Size: %s\n", formatBytes(stats.Bytes))
+		if stats.Entries > 0 {
+			fmt.Printf("Oldest: %s\n", stats.Oldest.Format("2006-01-02 15:04"))
+			fmt.Printf("Newest: %s\n", stats.Newest.Format("2006-01-02 15:04"))
+		}
+		return nil
+	},
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove all cached review results",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		removed, err := review.NewCache(config.Path(config.CacheDir)).Clear()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Removed %d cached review(s).\n", removed)
+		return nil
+	},
+}
+
+// formatBytes renders a byte count with a binary unit suffix.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
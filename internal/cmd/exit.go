@@ -0,0 +1,34 @@
+package cmd
+
+import "errors"
+
+// Exit codes of council review. Other commands exit with ExitError on any
+// failure.
+const (
+	ExitOK        = 0 // review ran and stayed below --fail-on
+	ExitError     = 1 // usage, configuration or input error
+	ExitThreshold = 2 // the verdict reached --fail-on
+	ExitAllFailed = 3 // every expert errored, so there is no verdict
+	ExitNoBackend = 4 // no AI backend could be set up, or every expert failed to reach it
+)
+
+// exitError is an error that carries the process exit code.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+func (e *exitError) Unwrap() error { return e.err }
+
+// ExitCode maps an error returned by Execute to the process exit code.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	var ee *exitError
+	if errors.As(err, &ee) {
+		return ee.code
+	}
+	return ExitError
+}
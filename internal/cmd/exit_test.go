@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/luuuc/council/internal/review"
+)
+
+func TestReviewExit(t *testing.T) {
+	tests := []struct {
+		name   string
+		result *review.SynthesizedResult
+		failOn review.FailOn
+		want   int
+	}{
+		{"no threshold", &review.SynthesizedResult{Verdict: review.VerdictBlock}, "", ExitOK},
+		{"below threshold", &review.SynthesizedResult{Verdict: review.VerdictComment}, review.FailOnBlock, ExitOK},
+		{"threshold reached", &review.SynthesizedResult{Verdict: review.VerdictBlock}, review.FailOnBlock, ExitThreshold},
+		{"blocking", &review.SynthesizedResult{Verdict: review.VerdictBlock, Blocking: true}, review.FailOnBlocking, ExitThreshold},
+		{"all failed", &review.SynthesizedResult{Verdict: review.VerdictPass, Errors: []string{"a: timeout"}}, review.FailOnBlock, ExitAllFailed},
+		{"backend unreachable", &review.SynthesizedResult{Verdict: review.VerdictPass, Errors: []string{"a: 529"}, Unavailable: true}, review.FailOnBlock, ExitNoBackend},
+	}
+	for _, tt := range tests {
+		if got := ExitCode(reviewExit(tt.result, tt.failOn)); got != tt.want {
+			t.Errorf("%s: exit code = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	if got := ExitCode(nil); got != ExitOK {
+		t.Errorf("ExitCode(nil) = %d, want %d", got, ExitOK)
+	}
+	if got := ExitCode(fmt.Errorf("bad flag")); got != ExitError {
+		t.Errorf("ExitCode(plain error) = %d, want %d", got, ExitError)
+	}
+	wrapped := fmt.Errorf("review: %w", &exitError{code: ExitNoBackend, err: fmt.Errorf("no backend")})
+	if got := ExitCode(wrapped); got != ExitNoBackend {
+		t.Errorf("ExitCode(wrapped) = %d, want %d", got, ExitNoBackend)
+	}
+}
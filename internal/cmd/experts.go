@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/luuuc/council/internal/config"
+	"github.com/luuuc/council/internal/expert"
+	"github.com/luuuc/council/internal/review"
+	"github.com/spf13/cobra"
+)
+
+var domainsJSON bool
+
+func init() {
+	rootCmd.AddCommand(expertsCmd)
+	expertsCmd.AddCommand(expertsDomainsCmd)
+
+	expertsDomainsCmd.Flags().BoolVar(&domainsJSON, "json", false, "Output in JSON format")
+}
+
+var expertsCmd = &cobra.Command{
+	Use:   "experts",
+	Short: "Inspect council experts",
+}
+
+var expertsDomainsCmd = &cobra.Command{
+	Use:   "domains",
+	Short: "Show the domain each expert resolves to and why",
+	Long: `Show the decision hierarchy and where each council expert sits in it.
+
+An expert's domain comes from the domain: field of its frontmatter when set,
+otherwise from the first keyword of the hierarchy its focus mentions,
+otherwise it falls in the lowest domain. The hierarchy is the built-in one
+(security > product > scope > convention > performance > quality) unless
+.council/config.yaml declares its own under domains:, highest priority
+first, e.g.
+
+  domains:
+    - security
+    - name: compliance
+      keywords: [gdpr, hipaa, audit]
+    - product
+    - name: accessibility
+      keywords: [a11y, wcag, screen reader]
+    - quality
+
+Examples:
+  council experts domains
+  council experts domains --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !config.Exists() {
+			return fmt.Errorf("council not initialized: run 'council init' first")
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		hierarchy, err := review.NewHierarchy(cfg.Domains)
+		if err != nil {
+			return fmt.Errorf("%s: %w", config.ConfigFile, err)
+		}
+
+		experts, err := expert.List()
+		if err != nil {
+			return fmt.Errorf("failed to list experts: %w", err)
+		}
+
+		if domainsJSON {
+			return printDomainsJSON(os.Stdout, hierarchy, experts)
+		}
+		printDomains(os.Stdout, hierarchy, experts)
+		return nil
+	},
+}
+
+// expertDomain is one row of 'council experts domains'.
+type expertDomain struct {
+	Expert string `json:"expert"`
+	review.DomainMatch
+}
+
+// printDomains writes the hierarchy and each expert's domain as a table.
+func printDomains(out io.Writer, h *review.Hierarchy, experts []*expert.Expert) {
+	names := make([]string, len(h.Domains))
+	for i, d := range h.Domains {
+		names[i] = d.Name.String()
+	}
+	_, _ = fmt.Fprintf(out, "Hierarchy: %s\n\n", strings.Join(names, " > "))
+
+	if len(experts) == 0 {
+		_, _ = fmt.Fprintln(out, "No experts in the council yet.")
+		return
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tDOMAIN\tWHY")
+	for _, e := range experts {
+		m := h.Classify(e)
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", e.ID, m.Domain, m.Reason)
+	}
+	_ = w.Flush()
+}
+
+// printDomainsJSON writes the hierarchy and each expert's domain as JSON.
+func printDomainsJSON(out io.Writer, h *review.Hierarchy, experts []*expert.Expert) error {
+	rows := make([]expertDomain, len(experts))
+	for i, e := range experts {
+		rows[i] = expertDomain{Expert: e.ID, DomainMatch: h.Classify(e)}
+	}
+	data, err := json.MarshalIndent(struct {
+		Domains []review.DomainDef `json:"domains"`
+		Experts []expertDomain     `json:"experts"`
+	}{h.Domains, rows}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	_, _ = fmt.Fprintln(out, string(data))
+	return nil
+}
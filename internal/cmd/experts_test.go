@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/luuuc/council/internal/config"
+	"github.com/luuuc/council/internal/expert"
+	"github.com/luuuc/council/internal/review"
+)
+
+func TestPrintDomains(t *testing.T) {
+	h, err := review.NewHierarchy([]config.DomainConfig{
+		{Name: "security"},
+		{Name: "accessibility", Keywords: []string{"wcag"}},
+		{Name: "quality"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	experts := []*expert.Expert{
+		{ID: "kent", Focus: "Pair programming"},
+		{ID: "marcy", Focus: "WCAG audits"},
+		{ID: "bruce", Focus: "Anything", Domain: "security"},
+	}
+
+	var out bytes.Buffer
+	printDomains(&out, h, experts)
+	got := out.String()
+
+	for _, want := range []string{
+		"Hierarchy: security > accessibility > quality",
+		"kent   quality        no keyword in focus; lowest domain",
+		`marcy  accessibility  focus mentions "wcag"`,
+		"bruce  security       domain: frontmatter",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/luuuc/council/internal/github"
+	"github.com/luuuc/council/internal/review"
+	"github.com/spf13/cobra"
+)
+
+var (
+	githubInput    string
+	githubRepo     string
+	githubPR       int
+	githubSHA      string
+	githubAPIURL   string
+	githubErrorLog string
+)
+
+// errorLogLines is how much of a failed review's log is quoted in the
+// error comment.
+const errorLogLines = 20
+
+func init() {
+	rootCmd.AddCommand(githubCmd)
+	githubCmd.AddCommand(githubPostCmd)
+
+	githubPostCmd.Flags().StringVar(&githubInput, "input", "-", "File with 'council review --output github-pr' JSON (- for stdin)")
+	githubPostCmd.Flags().StringVar(&githubRepo, "repo", "", "Repository as owner/name (default $GITHUB_REPOSITORY)")
+	githubPostCmd.Flags().IntVar(&githubPR, "pr", 0, "Pull request number (default from $GITHUB_EVENT_PATH)")
+	githubPostCmd.Flags().StringVar(&githubSHA, "sha", "", "Head commit for the check run (default from $GITHUB_EVENT_PATH)")
+	githubPostCmd.Flags().StringVar(&githubAPIURL, "api-url", "", "GitHub API root (default $GITHUB_API_URL or https://api.github.com)")
+	githubPostCmd.Flags().StringVar(&githubErrorLog, "error-log", "", "Post a 'review incomplete' notice quoting this log instead of a review")
+}
+
+var githubCmd = &cobra.Command{
+	Use:   "github",
+	Short: "Publish council reviews to GitHub",
+}
+
+var githubPostCmd = &cobra.Command{
+	Use:   "post",
+	Short: "Post a review and check run to a pull request",
+	Long: `Post 'council review --output github-pr' JSON to a pull request.
+
+Creates the PR review with its inline comments, then creates the
+"Council Review" check run on the head commit, or updates it when a run
+with that name already exists. Annotations are sent in batches of 50, the
+most GitHub accepts per request. When GitHub rejects the review with 422
+(usually an inline comment outside the diff), the review is posted as a
+plain PR comment instead.
+
+Each review carries a hidden marker, so later runs on the same pull
+request supersede earlier ones instead of piling up: the previous council
+reviews are minimized as outdated (and dismissed if they approved or
+requested changes), their inline comments are minimized when the finding is
+gone, and findings still commented on are not posted twice.
+
+With --error-log, a "review incomplete" comment quoting the first lines of
+the log and a neutral check run are posted instead of a review.
+
+The token comes from $GITHUB_TOKEN or $GH_TOKEN. Inside GitHub Actions the
+repository, pull request and head commit default to the workflow's.
+
+Examples:
+  council review --output github-pr --base main | council github post
+  council github post --input review.json --repo luuuc/council --pr 42 --sha $SHA
+  council github post --error-log council-stderr.log`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out, err := githubPayload(os.Stdin)
+		if err != nil {
+			return err
+		}
+
+		target, err := githubTarget()
+		if err != nil {
+			return err
+		}
+
+		token := githubToken()
+		if token == "" {
+			return fmt.Errorf("no GitHub token: set GITHUB_TOKEN or GH_TOKEN")
+		}
+
+		apiURL := githubAPIURL
+		if apiURL == "" {
+			apiURL = os.Getenv("GITHUB_API_URL")
+		}
+
+		res, err := github.NewClient(apiURL, token).Post(cmd.Context(), target, out)
+		switch {
+		case res.ReviewPosted:
+			fmt.Fprintf(os.Stderr, "✓ Posted review on %s#%d\n", target.Repo, target.Number)
+		case res.FallbackPosted:
+			fmt.Fprintf(os.Stderr, "✓ Review rejected by GitHub; posted it as a comment on %s#%d\n", target.Repo, target.Number)
+		}
+		if res.Superseded > 0 || res.Outdated > 0 {
+			fmt.Fprintf(os.Stderr, "✓ Marked %d earlier council review(s) and %d resolved comment(s) outdated\n", res.Superseded, res.Outdated)
+		}
+		if res.Repeated > 0 {
+			fmt.Fprintf(os.Stderr, "  %d comment(s) already on the PR were not posted again\n", res.Repeated)
+		}
+		if res.CheckRunID != 0 {
+			fmt.Fprintf(os.Stderr, "✓ Posted check run %d (%d annotations)\n", res.CheckRunID, res.Annotations)
+		}
+		return err
+	},
+}
+
+// githubPayload reads the review to post, or builds the error notice when
+// --error-log is set.
+func githubPayload(stdin io.Reader) (review.GitHubOutput, error) {
+	if githubErrorLog != "" {
+		data, err := os.ReadFile(githubErrorLog)
+		if err != nil && !os.IsNotExist(err) {
+			return review.GitHubOutput{}, fmt.Errorf("failed to read error log: %w", err)
+		}
+		lines := strings.SplitN(string(data), "\n", errorLogLines+1)
+		if len(lines) > errorLogLines {
+			lines = lines[:errorLogLines]
+		}
+		return review.FormatGitHubError(strings.Join(lines, "\n")), nil
+	}
+
+	var data []byte
+	var err error
+	if githubInput == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(githubInput)
+	}
+	if err != nil {
+		return review.GitHubOutput{}, fmt.Errorf("failed to read review: %w", err)
+	}
+
+	var out review.GitHubOutput
+	if err := json.Unmarshal(data, &out); err != nil {
+		return review.GitHubOutput{}, fmt.Errorf("failed to parse review (expected 'council review --output github-pr' JSON): %w", err)
+	}
+	return out, nil
+}
+
+// githubTarget resolves the pull request to post to from the flags, falling
+// back to the GitHub Actions environment.
+func githubTarget() (github.Target, error) {
+	t := github.Target{Repo: githubRepo, Number: githubPR, HeadSHA: githubSHA}
+	if t.Repo == "" {
+		t.Repo = os.Getenv("GITHUB_REPOSITORY")
+	}
+
+	if path := os.Getenv("GITHUB_EVENT_PATH"); path != "" && (t.Number == 0 || t.HeadSHA == "") {
+		number, sha, err := pullRequestEvent(path)
+		if err != nil {
+			return t, err
+		}
+		if t.Number == 0 {
+			t.Number = number
+		}
+		if t.HeadSHA == "" {
+			t.HeadSHA = sha
+		}
+	}
+
+	if t.Repo == "" || t.Number == 0 {
+		return t, fmt.Errorf("no pull request to post to: use --repo and --pr")
+	}
+	return t, nil
+}
+
+// githubToken returns the GitHub token from $GITHUB_TOKEN or $GH_TOKEN.
+func githubToken() string {
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		return token
+	}
+	return os.Getenv("GH_TOKEN")
+}
+
+// actionsPullRequest returns the pull request a GitHub Actions workflow runs
+// for, and false outside Actions or for other events.
+func actionsPullRequest() (github.Target, bool) {
+	path, repo := os.Getenv("GITHUB_EVENT_PATH"), os.Getenv("GITHUB_REPOSITORY")
+	if path == "" || repo == "" {
+		return github.Target{}, false
+	}
+	number, sha, err := pullRequestEvent(path)
+	if err != nil || number == 0 {
+		return github.Target{}, false
+	}
+	return github.Target{Repo: repo, Number: number, HeadSHA: sha}, true
+}
+
+// pullRequestEvent reads the pull request number and head SHA from a GitHub
+// Actions event payload. Both are zero for non-PR events.
+func pullRequestEvent(path string) (int, string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to read GitHub event: %w", err)
+	}
+
+	var event struct {
+		PullRequest struct {
+			Number int `json:"number"`
+			Head   struct {
+				SHA string `json:"sha"`
+			} `json:"head"`
+		} `json:"pull_request"`
+	}
+	if err := json.Unmarshal(data, &event); err != nil {
+		return 0, "", fmt.Errorf("failed to parse GitHub event: %w", err)
+	}
+	return event.PullRequest.Number, event.PullRequest.Head.SHA, nil
+}
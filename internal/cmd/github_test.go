@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/luuuc/council/internal/review"
+)
+
+func TestGitHubTargetFromEvent(t *testing.T) {
+	event := filepath.Join(t.TempDir(), "event.json")
+	if err := os.WriteFile(event, []byte(`{"pull_request": {"number": 42, "head": {"sha": "abc123"}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GITHUB_EVENT_PATH", event)
+	t.Setenv("GITHUB_REPOSITORY", "luuuc/council")
+
+	githubRepo, githubPR, githubSHA = "", 0, ""
+	defer func() { githubRepo, githubPR, githubSHA = "", 0, "" }()
+
+	target, err := githubTarget()
+	if err != nil {
+		t.Fatalf("githubTarget() error = %v", err)
+	}
+	if target.Repo != "luuuc/council" || target.Number != 42 || target.HeadSHA != "abc123" {
+		t.Errorf("target = %+v", target)
+	}
+
+	// Flags win over the event
+	githubPR, githubSHA = 7, "def456"
+	target, _ = githubTarget()
+	if target.Number != 7 || target.HeadSHA != "def456" {
+		t.Errorf("flags should override the event, got %+v", target)
+	}
+}
+
+func TestGitHubTargetMissing(t *testing.T) {
+	t.Setenv("GITHUB_EVENT_PATH", "")
+	t.Setenv("GITHUB_REPOSITORY", "")
+
+	githubRepo, githubPR, githubSHA = "", 0, ""
+	if _, err := githubTarget(); err == nil {
+		t.Error("expected an error without a repository and pull request")
+	}
+}
+
+func TestGitHubPayloadErrorLog(t *testing.T) {
+	var lines []string
+	for i := 1; i <= 30; i++ {
+		lines = append(lines, fmt.Sprintf("line %d", i))
+	}
+	log := filepath.Join(t.TempDir(), "stderr.log")
+	if err := os.WriteFile(log, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	githubErrorLog = log
+	defer func() { githubErrorLog = "" }()
+
+	out, err := githubPayload(strings.NewReader(""))
+	if err != nil {
+		t.Fatalf("githubPayload() error = %v", err)
+	}
+	if out.CheckRun.Conclusion != "neutral" || out.Review.Event != review.GitHubComment {
+		t.Errorf("unexpected error payload: %+v", out)
+	}
+	if !strings.Contains(out.Review.Body, "line 20") || strings.Contains(out.Review.Body, "line 21") {
+		t.Errorf("body should quote the first %d lines:\n%s", errorLogLines, out.Review.Body)
+	}
+}
+
+func TestGitHubPayloadStdin(t *testing.T) {
+	githubInput, githubErrorLog = "-", ""
+
+	out, err := githubPayload(strings.NewReader(`{"review": {"event": "APPROVE", "body": "ok"}, "check_run": {"name": "Council Review"}}`))
+	if err != nil {
+		t.Fatalf("githubPayload() error = %v", err)
+	}
+	if out.Review.Event != review.GitHubApprove || out.CheckRun.Name != "Council Review" {
+		t.Errorf("payload = %+v", out)
+	}
+
+	if _, err := githubPayload(strings.NewReader("not json")); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+}
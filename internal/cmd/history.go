@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/luuuc/council/internal/history"
+	"github.com/luuuc/council/internal/review"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyJSON   bool
+	historyLimit  int
+	historyBranch string
+)
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+	historyCmd.AddCommand(historyListCmd)
+	historyCmd.AddCommand(historyShowCmd)
+	historyCmd.AddCommand(historyDiffCmd)
+
+	historyCmd.PersistentFlags().BoolVar(&historyJSON, "json", false, "Output as JSON")
+	historyListCmd.Flags().IntVarP(&historyLimit, "limit", "n", 20, "Show at most N reviews (0 for all)")
+	historyListCmd.Flags().StringVar(&historyBranch, "branch", "", "Only show reviews of this branch")
+}
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Browse past council reviews",
+	Long: `Browse reviews recorded in .council/history/.
+
+Every 'council review' is recorded with its time, git HEAD and branch, pack,
+backend and model, and the full result. Review IDs can be abbreviated to any
+unique prefix; "latest" refers to the most recent review.
+
+Examples:
+  council history list                  # Recent reviews
+  council history list --branch feature # Reviews of one branch
+  council history show latest           # Full result of the last review
+  council history diff 3f2a91c0 latest  # How the verdicts moved`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return historyListCmd.RunE(cmd, args)
+	},
+}
+
+var historyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List recorded reviews, newest first",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := history.List()
+		if err != nil {
+			return err
+		}
+
+		// Newest first, filtered by branch, capped by --limit
+		var shown []*history.Entry
+		for i := len(entries) - 1; i >= 0; i-- {
+			e := entries[i]
+			if historyBranch != "" && e.Branch != historyBranch {
+				continue
+			}
+			shown = append(shown, e)
+			if historyLimit > 0 && len(shown) == historyLimit {
+				break
+			}
+		}
+
+		if historyJSON {
+			return printJSON(shown)
+		}
+
+		if len(shown) == 0 {
+			fmt.Println("No reviews recorded yet.")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintf(w, "ID\tTIME\tBRANCH\tHEAD\tPACK\tVERDICT\tEXPERTS\n")
+		for _, e := range shown {
+			verdict := string(e.Result.Verdict)
+			if e.Result.Blocking {
+				verdict += " (blocking)"
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%d\n",
+				e.ID,
+				e.Time.Local().Format("2006-01-02 15:04"),
+				orDash(e.Branch),
+				orDash(e.ShortHead()),
+				orDash(e.Pack),
+				verdict,
+				len(e.Result.Perspectives),
+			)
+		}
+		return w.Flush()
+	},
+}
+
+var historyShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Show a recorded review",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		e, err := history.Get(args[0])
+		if err != nil {
+			return err
+		}
+
+		if historyJSON {
+			return printJSON(e)
+		}
+
+		fmt.Printf("Review %s — %s\n", e.ID, e.Time.Local().Format("2006-01-02 15:04:05"))
+		if e.Branch != "" || e.Head != "" {
+			fmt.Printf("Revision: %s %s\n", orDash(e.Branch), orDash(e.ShortHead()))
+		}
+		fmt.Printf("Backend: %s\n", e.Backend)
+		if e.Model != "" {
+			fmt.Printf("Model: %s\n", e.Model)
+		}
+		fmt.Println()
+		fmt.Print(review.FormatHuman(e.Result, e.Pack, len(e.Result.Perspectives)))
+		return nil
+	},
+}
+
+var historyDiffCmd = &cobra.Command{
+	Use:   "diff <from> <to>",
+	Short: "Compare how the council judged two reviews",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, err := history.Get(args[0])
+		if err != nil {
+			return err
+		}
+		to, err := history.Get(args[1])
+		if err != nil {
+			return err
+		}
+
+		cmp := history.Compare(from, to)
+
+		if historyJSON {
+			return printJSON(cmp)
+		}
+
+		fmt.Printf("From: %s  %s %s\n", from.ID, orDash(from.Branch), orDash(from.ShortHead()))
+		fmt.Printf("To:   %s  %s %s\n\n", to.ID, orDash(to.Branch), orDash(to.ShortHead()))
+		fmt.Printf("Verdict: %s → %s\n\n", from.Result.Verdict, to.Result.Verdict)
+
+		unchanged := 0
+		for _, c := range cmp.Experts {
+			if !c.Changed() {
+				unchanged++
+				continue
+			}
+			fmt.Printf("%s: %s → %s\n", c.Expert, orDash(string(c.From)), orDash(string(c.To)))
+			for _, n := range c.RemovedNotes {
+				fmt.Printf("  - %s\n", n)
+			}
+			for _, n := range c.AddedNotes {
+				fmt.Printf("  + %s\n", n)
+			}
+			fmt.Println()
+		}
+		if unchanged > 0 {
+			fmt.Printf("%d expert(s) unchanged.\n", unchanged)
+		}
+		return nil
+	},
+}
+
+// printJSON writes v to stdout as indented JSON.
+func printJSON(v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+// orDash returns s, or "-" when s is blank.
+func orDash(s string) string {
+	if strings.TrimSpace(s) == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/luuuc/council/internal/git"
+	"github.com/luuuc/council/internal/hooks"
+	"github.com/luuuc/council/internal/review"
+	"github.com/spf13/cobra"
+)
+
+var (
+	hooksPack    string
+	hooksFailOn  string
+	hooksTimeout int
+	hooksForce   bool
+	hooksInstall []string
+	hooksRemove  []string
+)
+
+func init() {
+	rootCmd.AddCommand(hooksCmd)
+	hooksCmd.AddCommand(hooksInstallCmd)
+	hooksCmd.AddCommand(hooksUninstallCmd)
+	hooksCmd.AddCommand(hooksStatusCmd)
+	hooksCmd.AddCommand(hooksRunCmd)
+
+	for _, c := range []*cobra.Command{hooksInstallCmd, hooksRunCmd} {
+		c.Flags().StringVar(&hooksPack, "pack", "", "Review with a specific pack")
+		c.Flags().StringVar(&hooksFailOn, "fail-on", string(review.FailOnBlock), "Verdict that fails the hook: comment, block, escalate, blocking")
+		c.Flags().IntVar(&hooksTimeout, "timeout", 120, "Seconds before the review gives up and lets git proceed")
+	}
+	hooksInstallCmd.Flags().StringSliceVar(&hooksInstall, "hook", []string{"pre-commit"}, "Hooks to install: pre-commit, pre-push")
+	hooksInstallCmd.Flags().BoolVar(&hooksForce, "force", false, "Replace existing hooks not written by council")
+	hooksUninstallCmd.Flags().StringSliceVar(&hooksRemove, "hook", hooks.Names, "Hooks to remove")
+}
+
+var hooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "Review changes from git pre-commit and pre-push hooks",
+	Long: `Install git hooks that run a council review before each commit or push.
+
+The pre-commit hook reviews staged changes; the pre-push hook reviews the
+commits being pushed. The hook fails when a blocking expert blocks or the
+overall verdict reaches --fail-on (block by default).
+
+Hooks never stand in the way of git for reasons other than the verdict:
+if the review exceeds --timeout, the backend is unavailable or council is
+not on PATH, a warning is printed and git proceeds.
+
+Bypass a single run with COUNCIL_SKIP_HOOKS=1 or git --no-verify.
+
+Examples:
+  council hooks install --pack go                 # pre-commit, fail on block
+  council hooks install --hook pre-push --fail-on escalate --timeout 300
+  council hooks status
+  council hooks uninstall`,
+}
+
+var hooksInstallCmd = &cobra.Command{
+	Use:          "install",
+	Short:        "Install council git hooks",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, err := review.ParseFailOn(hooksFailOn); err != nil {
+			return err
+		}
+
+		dir, err := git.NewRepo("").HooksDir()
+		if err != nil {
+			return err
+		}
+
+		opts := hooks.Options{Pack: hooksPack, FailOn: hooksFailOn, Timeout: hooksTimeout}
+		for _, name := range hooksInstall {
+			if err := hooks.Install(dir, name, opts, hooksForce); err != nil {
+				return err
+			}
+			fmt.Printf("✓ Installed %s hook\n", name)
+		}
+		fmt.Printf("\nBypass with %s=1 or git --no-verify.\n", hooks.BypassEnv)
+		return nil
+	},
+}
+
+var hooksUninstallCmd = &cobra.Command{
+	Use:          "uninstall",
+	Short:        "Remove council git hooks",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := git.NewRepo("").HooksDir()
+		if err != nil {
+			return err
+		}
+
+		for _, name := range hooksRemove {
+			removed, err := hooks.Uninstall(dir, name)
+			if err != nil {
+				return err
+			}
+			if removed {
+				fmt.Printf("✓ Removed %s hook\n", name)
+			}
+		}
+		return nil
+	},
+}
+
+var hooksStatusCmd = &cobra.Command{
+	Use:          "status",
+	Short:        "Show which council git hooks are installed",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := git.NewRepo("").HooksDir()
+		if err != nil {
+			return err
+		}
+
+		for _, name := range hooks.Names {
+			state, command, err := hooks.Status(dir, name)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%-11s %s\n", name+":", state)
+			if command != "" {
+				fmt.Printf("            runs: %s\n", command)
+			}
+		}
+		if os.Getenv(hooks.BypassEnv) != "" {
+			fmt.Printf("\n%s is set: hooks are currently bypassed.\n", hooks.BypassEnv)
+		}
+		return nil
+	},
+}
+
+var hooksRunCmd = &cobra.Command{
+	Use:          "run <hook>",
+	Short:        "Run the review for a git hook (called by the installed hook)",
+	Args:         cobra.ExactArgs(1),
+	Hidden:       true,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runHook(cmd.Context(), args[0], os.Stdin)
+	},
+}
+
+// runHook reviews the changes a git hook is about to let through and
+// returns an error, failing the hook, only when the verdict reaches the
+// threshold. Every other failure is reported and lets git proceed.
+func runHook(ctx context.Context, name string, stdin io.Reader) error {
+	if os.Getenv(hooks.BypassEnv) != "" {
+		return nil
+	}
+
+	failOn, err := review.ParseFailOn(hooksFailOn)
+	if err != nil {
+		return err
+	}
+
+	var sels []git.Selection
+	switch name {
+	case "pre-commit":
+		sels = []git.Selection{{Staged: true}}
+	case "pre-push":
+		remoteDefault, _ := git.NewRepo("").RemoteDefault()
+		sels = prePushSelections(stdin, remoteDefault)
+	default:
+		return fmt.Errorf("unknown hook '%s': must be one of: %s", name, strings.Join(hooks.Names, ", "))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(hooksTimeout)*time.Second)
+	defer cancel()
+
+	reviewPack = hooksPack
+	for _, sel := range sels {
+		reviewStaged, reviewRange, reviewCommit = sel.Staged, sel.Range, sel.Commit
+
+		run, err := performReview(ctx)
+		switch {
+		case errors.Is(err, errNoChanges):
+			continue
+		case ctx.Err() != nil:
+			fmt.Fprintf(os.Stderr, "council: review exceeded %ds, skipping the %s check\n", hooksTimeout, name)
+			return nil
+		case err != nil:
+			fmt.Fprintf(os.Stderr, "council: review skipped: %v\n", err)
+			return nil
+		}
+
+		fmt.Fprint(os.Stderr, review.FormatHuman(run.result, run.packName, run.experts))
+		if run.result.Blocking || failOn.Reached(run.result) {
+			return &exitError{code: ExitThreshold, err: fmt.Errorf("council %s check failed: verdict %s (fail-on %s); bypass with %s=1", name, run.result.Verdict, failOn, hooks.BypassEnv)}
+		}
+	}
+	return nil
+}
+
+// prePushSelections turns the ref lines git feeds a pre-push hook
+// ("<local ref> <local sha> <remote ref> <remote sha>") into the changes to
+// review. Deleted refs are skipped. New branches are reviewed from their
+// merge base with remoteDefault, or as a single commit when it is unknown.
+func prePushSelections(r io.Reader, remoteDefault string) []git.Selection {
+	var sels []git.Selection
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 4 {
+			continue
+		}
+		local, remote := fields[1], fields[3]
+
+		switch {
+		case isZeroSHA(local):
+			continue
+		case !isZeroSHA(remote):
+			sels = append(sels, git.Selection{Range: remote + ".." + local})
+		case remoteDefault != "":
+			sels = append(sels, git.Selection{Range: remoteDefault + "..." + local})
+		default:
+			sels = append(sels, git.Selection{Commit: local})
+		}
+	}
+	return sels
+}
+
+// isZeroSHA reports whether sha is git's all-zeros placeholder for a
+// missing ref.
+func isZeroSHA(sha string) bool {
+	return strings.Trim(sha, "0") == ""
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/luuuc/council/internal/git"
+)
+
+func TestPrePushSelections(t *testing.T) {
+	zero := strings.Repeat("0", 40)
+	local := strings.Repeat("a", 40)
+	remote := strings.Repeat("b", 40)
+
+	input := strings.Join([]string{
+		"refs/heads/feature " + local + " refs/heads/feature " + remote,
+		"refs/heads/new " + local + " refs/heads/new " + zero,
+		"(delete) " + zero + " refs/heads/old " + remote,
+		"garbage",
+	}, "\n")
+
+	got := prePushSelections(strings.NewReader(input), "origin/main")
+	want := []git.Selection{
+		{Range: remote + ".." + local},
+		{Range: "origin/main..." + local},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d selections, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("selection %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	// Without a known remote default, a new branch is reviewed as its tip commit
+	got = prePushSelections(strings.NewReader("refs/heads/new "+local+" refs/heads/new "+zero), "")
+	if len(got) != 1 || got[0].Commit != local {
+		t.Errorf("new branch without remote default = %+v, want commit %s", got, local)
+	}
+}
@@ -100,6 +100,9 @@ var packsShowCmd = &cobra.Command{
 		if p.Source == "builtin" {
 			fmt.Printf("Source: builtin\n")
 		}
+		if p.Policy != nil {
+			fmt.Printf("Verdict policy: %s\n", p.Policy)
+		}
 		fmt.Println()
 
 		if len(p.Members) == 0 {
@@ -113,6 +116,9 @@ var packsShowCmd = &cobra.Command{
 			if m.Blocking {
 				blocking = " [blocking]"
 			}
+			if !m.BackendSpec.IsZero() {
+				blocking += " [" + m.BackendSpec.String() + "]"
+			}
 			fmt.Printf("  - %s%s\n", m.ID, blocking)
 		}
 
@@ -1,12 +1,21 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
 
 	"github.com/luuuc/council/internal/config"
 	"github.com/luuuc/council/internal/expert"
+	"github.com/luuuc/council/internal/git"
+	"github.com/luuuc/council/internal/github"
+	"github.com/luuuc/council/internal/history"
 	"github.com/luuuc/council/internal/pack"
 	"github.com/luuuc/council/internal/review"
 	"github.com/spf13/cobra"
@@ -21,6 +30,20 @@ var (
 	reviewBackend  string
 	reviewProvider string
 	reviewModel    string
+	reviewPerFile  bool
+	reviewBudget   int
+	reviewNoCache  bool
+	reviewNoSave   bool
+	reviewBase     string
+	reviewStaged   bool
+	reviewCommit   string
+	reviewRange    string
+	reviewFailOn   string
+	reviewQuality  string
+	reviewIncr     bool
+	reviewRounds   int
+	reviewChair    bool
+	reviewMaxCost  float64
 )
 
 func init() {
@@ -29,11 +52,25 @@ func init() {
 	reviewCmd.Flags().StringVar(&reviewPack, "pack", "", "Review with a specific pack")
 	reviewCmd.Flags().StringVar(&reviewExpert, "expert", "", "Review with a single expert")
 	reviewCmd.Flags().StringVar(&reviewFile, "file", "", "File to review (reads diff from stdin if omitted)")
+	reviewCmd.Flags().StringVar(&reviewBase, "base", "", "Review changes since the merge base with this ref (e.g. main)")
+	reviewCmd.Flags().BoolVar(&reviewStaged, "staged", false, "Review staged changes")
+	reviewCmd.Flags().StringVar(&reviewCommit, "commit", "", "Review the changes of a single commit")
+	reviewCmd.Flags().StringVar(&reviewRange, "range", "", "Review a revision range (e.g. main..feature)")
+	reviewCmd.Flags().BoolVar(&reviewIncr, "incremental", false, "With --base, review only the commits since the last recorded review of this branch")
+	reviewCmd.Flags().StringVar(&reviewFailOn, "fail-on", "", "Exit with code 2 when the verdict reaches: comment, block, escalate, blocking")
 	reviewCmd.Flags().BoolVar(&reviewJSON, "json", false, "Output as JSON")
-	reviewCmd.Flags().StringVar(&reviewOutput, "output", "", "Output format: github-pr (implies --json)")
+	reviewCmd.Flags().StringVar(&reviewOutput, "output", "", "Output format: github-pr, gitlab-mr, sarif (implies --json)")
+	reviewCmd.Flags().StringVar(&reviewQuality, "code-quality", "", "With --output gitlab-mr, also write a GitLab Code Quality report to this file")
 	reviewCmd.Flags().StringVar(&reviewBackend, "backend", "", "Backend: cli or api")
 	reviewCmd.Flags().StringVar(&reviewProvider, "provider", "", "API provider: anthropic, openai, ollama, github")
 	reviewCmd.Flags().StringVar(&reviewModel, "model", "", "LLM model override")
+	reviewCmd.Flags().IntVar(&reviewRounds, "rounds", 1, "Debate rounds: above 1, experts review blind, then revise after reading each other's verdicts")
+	reviewCmd.Flags().BoolVar(&reviewChair, "chair", false, "Review per expert, then have one more LLM call synthesize the verdicts")
+	reviewCmd.Flags().Float64Var(&reviewMaxCost, "max-cost", 0, "Stop the review before its estimated API cost exceeds this many US dollars (0 = no limit)")
+	reviewCmd.Flags().BoolVar(&reviewPerFile, "per-file", false, "Review each file of the diff separately (automatic when the diff exceeds the token budget)")
+	reviewCmd.Flags().BoolVar(&reviewNoCache, "no-cache", false, "Ignore cached results and don't store this review")
+	reviewCmd.Flags().BoolVar(&reviewNoSave, "no-history", false, "Don't record this review in .council/history/")
+	reviewCmd.Flags().IntVar(&reviewBudget, "token-budget", 0, fmt.Sprintf("Input token budget per request (default %d)", review.DefaultTokenBudget))
 }
 
 var reviewCmd = &cobra.Command{
@@ -45,18 +82,144 @@ All experts see each other's perspectives and can react to them.
 The tension between perspectives produces richer, more nuanced reviews.
 Falls back to per-expert review for small-context models.
 
-Input can be a diff from stdin or a file via --file.
+Input can be a diff from stdin, a file via --file, or changes read straight
+from git: --base <ref> (everything since the merge base, like a PR),
+--staged, --commit <sha> or --range <a..b>. With the git flags the branch
+name and commit messages are passed to the council as context, and files
+marked linguist-generated or linguist-vendored in .gitattributes are left out.
 
-Note: per-file review (--provider github) reviews each file in isolation.
-Cross-file issues (e.g. function defined in A, misused in B) are invisible.
-Use BYOK (--provider anthropic/openai) for cross-file analysis.
+Files can be kept out of every review with review.ignore / review.include
+globs in .council/config.yaml (a pack's own review: block overrides them):
+
+  review:
+    ignore: [go.sum, vendor/, "**/__snapshots__/**", db/migrate/]
+
+Filtered files are listed in the output.
+
+In a monorepo, .council/routes.yaml sends each file of a diff to its own
+council. Routes map path globs to a pack or a list of experts; the last
+matching route wins, as in CODEOWNERS, and unmatched files go to the full
+council. Routing applies when neither --pack nor --expert is given:
+
+  routes:
+    - paths: [app/, lib/]
+      pack: rails
+    - paths: [services/]
+      pack: go
+    - paths: [services/billing/]
+      experts: [the-threat-modeler]
+
+The overall verdict follows the pack's verdict policy, declared with
+policy: in the pack YAML: strict (default; the most severe verdict wins),
+majority, confidence-weighted, domain-veto (only security and product
+experts can block) or quorum (a block needs N blocking verdicts). The
+policy is shown next to the verdict. Blocking members block regardless.
+
+When the configured provider is down, rate limited or rejects the
+credentials, ai.fallbacks in .council/config.yaml lists the backends to
+try next, in order:
+
+  ai:
+    provider: anthropic
+    fallbacks: [openai, ollama, cli]
+
+Every perspective records the backend that produced it; those served by a
+fallback are marked as such in the output.
+
+Every review is recorded in .council/history/ (see 'council history');
+use --no-history to skip it.
+
+--incremental, with --base, reviews only what changed since the last
+recorded review of the same branch and pack, e.g. the commits pushed to a
+PR since council last ran on it. Findings that review made on files that
+haven't changed since are carried forward, shown separately and counted in
+the verdict. When there is no earlier review, or a force-push rewrote the
+commit it covered, the whole branch is reviewed as usual; when nothing
+changed since, there is nothing to review and it exits 0. It needs a
+checked-out branch. On a pull request in GitHub Actions it instead
+continues from the last council review posted to the pull request, which
+records the commit it covered and its findings.
+
+--rounds N turns the review into a debate. Round one is a blind review by
+each expert on its own; in every later round each expert reads the others'
+verdicts and findings and may revise, concede or push back. The debate
+stops early once a round changes no verdict. The output shows how each
+verdict moved between rounds and whether the council converged. Each round
+costs one call per expert.
+
+--chair reviews in two stages: each expert reviews on its own, then one
+more call, the chair, reads every verdict and writes the agreements,
+tension and summary, and picks the overall verdict. The chair can raise the
+verdict but never lower it below the most severe expert verdict, and can't
+lift a block from a blocking member; a pack's verdict policy still has the
+last word. If the chair's call fails, the heuristic synthesis is kept.
+
+API reviews report the input and output tokens of each expert and of the
+whole review, with a cost estimated from council's price table (Ollama is
+free; models missing from the table are shown as unpriced). --max-cost
+caps that cost: before each call, its prompt and the most output it may
+return are priced, the call's output is capped there, and calls that could
+take the review over the budget are not sent. The experts they would have
+served are listed as errors, and a per-file review stops at the first file
+that doesn't fit, listing the rest as skipped. CLI backends report no usage
+and are not capped, nor are unpriced models: --max-cost refuses to run when
+the backend is one, and warns about fallbacks and expert backends that are.
+A cached review reports no usage: it cost nothing.
+
+Results are cached in .council/cache/, keyed by the submission, the expert
+personas, the backend and model, and the prompt version. Rerunning the same
+review (after a rebase, in a CI retry) returns the cached result. Use
+--no-cache to force a fresh review, and 'council cache clear' to empty it.
+
+Per-file review (--per-file) splits a diff and runs the council on each
+file separately. It starts automatically when the diff exceeds the token
+budget (--token-budget, sized for the GitHub Models free tier by default).
+Files too large for the budget, or beyond the file limit, are skipped and
+listed in the output. Each file is reviewed in isolation, so cross-file
+issues (e.g. function defined in A, misused in B) are invisible.
+Use BYOK (--provider anthropic/openai) with a larger --token-budget for
+cross-file analysis.
+
+--output gitlab-mr writes the merge request payloads as JSON: a summary
+note, one discussion per finding on an added line (positioned with the
+base/start/head SHAs from GitLab CI, or HEAD and its merge base with
+--base), and a Code Quality report. --code-quality <file> also writes the
+report on its own, ready for artifacts:reports:codequality.
+
+--output sarif writes a SARIF 2.1.0 log for code-scanning dashboards and
+IDE SARIF viewers. Every finding with a file and line becomes a result
+whose rule is "<domain>/<expert-id>"; levels follow the finding severity,
+falling back to the expert's verdict (block and escalate are errors,
+comment a warning, pass a note). The pack, backend and model are recorded
+in the run properties.
+
+Use --fail-on to gate CI or scripts on the verdict. The exit code is the
+same whatever the output format:
+
+  0  review passed (verdict below --fail-on, or no --fail-on given)
+  1  usage, configuration or input error
+  2  verdict reached --fail-on (comment, block, escalate, or blocking:
+     a blocking expert blocked)
+  3  every expert errored, so there is no verdict
+  4  no AI backend available, or every expert failed to reach it
 
 Examples:
+  council review --pack rails --base main
+  council review --pack go --base main --fail-on block
+  council review --pack go --base main --incremental
+  council review --pack go --base main --rounds 3
+  council review --pack go --base main --chair
+  council review --pack go --base main --provider openai --max-cost 0.50
+  council review --pack go --staged
+  council review --commit HEAD
   git diff main | council review --pack rails
   council review --pack code --file src/controller.rb
   council review --expert the-tdd-advocate --file lib/utils.rb
   git diff main | council review --pack rails --json
-  git diff main | council review --backend api --provider github --output github-pr`,
+  git diff main | council review --pack rails --per-file
+  git diff main | council review --backend api --provider github --output github-pr
+  council review --pack go --base main --output sarif > council.sarif
+  council review --pack go --base origin/main --output gitlab-mr --code-quality gl-code-quality-report.json`,
 	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runReview(cmd)
@@ -64,32 +227,122 @@ Examples:
 }
 
 func runReview(cmd *cobra.Command) error {
+	switch reviewOutput {
+	case "", "github-pr", "gitlab-mr", "sarif":
+	default:
+		return fmt.Errorf("unknown output format '%s': must be github-pr, gitlab-mr or sarif", reviewOutput)
+	}
+	if reviewQuality != "" && reviewOutput != "gitlab-mr" {
+		return fmt.Errorf("--code-quality requires --output gitlab-mr")
+	}
+	if reviewRounds < 1 {
+		return fmt.Errorf("--rounds must be at least 1, got %d", reviewRounds)
+	}
+	if reviewMaxCost < 0 {
+		return fmt.Errorf("--max-cost must not be negative, got %g", reviewMaxCost)
+	}
+
+	var failOn review.FailOn
+	if reviewFailOn != "" {
+		var err error
+		if failOn, err = review.ParseFailOn(reviewFailOn); err != nil {
+			return err
+		}
+	}
+
+	run, err := performReview(cmd.Context())
+	if errors.Is(err, errNothingNew) {
+		// The last review still stands; there is nothing to print or post
+		fmt.Fprintf(os.Stderr, "council: %v, the last review still stands.\n", err)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := printReview(run); err != nil {
+		return err
+	}
+	return reviewExit(run.result, failOn)
+}
+
+// reviewExit turns a finished review into the command's exit status: all
+// experts failing, or the verdict reaching failOn, is an error carrying its
+// own exit code. Experts that all failed because the backend couldn't be
+// reached exit as ExitNoBackend. An empty failOn never fails on the verdict.
+func reviewExit(result *review.SynthesizedResult, failOn review.FailOn) error {
+	if result.AllFailed() {
+		if result.Unavailable {
+			return &exitError{code: ExitNoBackend, err: fmt.Errorf("review failed: the AI backend could not be reached")}
+		}
+		return &exitError{code: ExitAllFailed, err: fmt.Errorf("review failed: no expert produced a verdict")}
+	}
+	if failOn != "" && failOn.Reached(result) {
+		return &exitError{code: ExitThreshold, err: fmt.Errorf("review verdict %s reached --fail-on %s", result.Verdict, failOn)}
+	}
+	return nil
+}
+
+// reviewRun is a finished review and what it was run with.
+type reviewRun struct {
+	result   *review.SynthesizedResult
+	packName string
+	experts  int
+	members  []*expert.Expert // every expert that took part, routed ones included
+	backend  string           // see review.BackendName
+	model    string
+	sub      review.Submission
+}
+
+// performReview resolves experts, reads the submission and runs the review
+// as configured by the review flags. Progress goes to stderr.
+func performReview(ctx context.Context) (*reviewRun, error) {
 	// Load config
 	cfg, err := config.Load()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Resolve experts
 	inputs, packName, err := resolveReviewExperts()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if len(inputs) == 0 {
-		return fmt.Errorf("no experts to review with — add experts or specify a --pack")
+		return nil, fmt.Errorf("no experts to review with — add experts or specify a --pack")
 	}
 
-	// Read submission
-	sub, err := readSubmission()
+	// Continue from the last review of this branch with --incremental
+	since, prev, err := incrementalBase(ctx, packName)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	// Read submission, dropping files excluded by path rules
+	sub, filtered, err := readSubmission(reviewPathRules(cfg, packName), since)
+	if since != "" && errors.Is(err, errNoChanges) {
+		return nil, fmt.Errorf("%w since %s", errNothingNew, since[:min(8, len(since))])
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(filtered) > 0 {
+		fmt.Fprintf(os.Stderr, "Filtered %d file(s) out of the review.\n", len(filtered))
 	}
 
 	// Build backend
-	backend, err := buildBackend(cfg)
+	builder := &review.Builder{
+		Config:   cfg,
+		Override: review.BackendSpec{Backend: reviewBackend, Provider: reviewProvider, Model: reviewModel},
+		// Stream only when someone is watching: progress is drawn on stderr.
+		Stream: isTerminal(os.Stderr),
+		Skipped: func(spec review.BackendSpec, err error) {
+			fmt.Fprintf(os.Stderr, "Warning: skipping fallback %s: %v\n", spec, err)
+		},
+	}
+	backend, err := builder.Build(review.BackendSpec{})
 	if err != nil {
-		return fmt.Errorf("cannot run review: %w", err)
+		return nil, &exitError{code: ExitNoBackend, err: fmt.Errorf("cannot run review: %w", err)}
 	}
 
 	runner := &review.Runner{
@@ -97,7 +350,23 @@ func runReview(cmd *cobra.Command) error {
 		Options: review.ReviewOptions{
 			Concurrency: cfg.AI.Concurrency,
 			Timeout:     cfg.AI.Timeout,
+			Rounds:      reviewRounds,
+			Chair:       reviewChair,
 		},
+		Progress: newProgressPrinter(os.Stderr, isTerminal(os.Stderr)),
+		Backends: review.NewRegistry(builder.Build),
+	}
+	if !reviewNoCache && config.Exists() {
+		runner.Cache = review.NewCache(config.Path(config.CacheDir))
+	}
+	if reviewMaxCost > 0 {
+		if err := checkBudgeted(runner, inputs); err != nil {
+			return nil, err
+		}
+		runner.Budget = review.NewBudget(reviewMaxCost)
+	}
+	if runner.Policy, err = packPolicy(packName); err != nil {
+		return nil, err
 	}
 
 	// Progress message
@@ -107,21 +376,149 @@ func runReview(cmd *cobra.Command) error {
 		fmt.Fprintf(os.Stderr, "Reviewing with %d experts...\n", len(inputs))
 	}
 
+	// Route files to councils when .council/routes.yaml applies
+	routed, err := routeGroups(cfg, sub, inputs)
+	if err != nil {
+		return nil, err
+	}
+	var groups []review.RouteGroup
+	if routed != nil {
+		groups = routed.groups
+		filtered = append(filtered, routed.filtered...)
+	}
+
+	// Place every expert in the configured domain hierarchy
+	hierarchy, err := review.NewHierarchy(cfg.Domains)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", config.ConfigFile, err)
+	}
+	members := make([]*expert.Expert, 0, len(inputs))
+	for _, in := range inputs {
+		members = append(members, in.Expert)
+	}
+	for _, g := range groups {
+		for _, in := range g.Inputs {
+			members = append(members, in.Expert)
+		}
+	}
+	hierarchy.Tag(members)
+
+	// Decide between whole-diff and per-file review
+	var chunks review.ChunkResult
+	perFile := false
+	if routed == nil {
+		chunks, perFile, err = perFileChunks(sub, inputs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Run review
-	result := runner.Run(cmd.Context(), inputs, sub)
+	var result *review.SynthesizedResult
+	if routed != nil {
+		files := 0
+		for _, g := range groups {
+			files += len(g.Files)
+		}
+		fmt.Fprintf(os.Stderr, "Routed review: %d files across %d councils (%d skipped)...\n", files, len(groups), len(routed.skipped))
+		result = runner.RunRouted(ctx, groups, routed.skipped, sub)
+	} else if perFile {
+		fmt.Fprintf(os.Stderr, "Per-file review: %d files (%d skipped)...\n", len(chunks.Files), len(chunks.Skipped))
+		result = runner.RunPerFile(ctx, inputs, chunks, sub)
+	} else {
+		result = runner.Run(ctx, inputs, sub)
+	}
+	if result.Cached {
+		fmt.Fprintln(os.Stderr, "Using cached review result (--no-cache to rerun).")
+	}
+	result.Filtered = filtered
+	if prev != nil {
+		byID := make(map[string]*expert.Expert, len(members))
+		for _, m := range members {
+			byID[m.ID] = m
+		}
+		result.CarryForward(prev, since, review.DiffPaths(sub.Content), runner.Policy, byID)
+	} else {
+		result.Since = since
+	}
+
+	// A branch review covers everything up to HEAD; later incremental
+	// reviews continue from there
+	if reviewBase != "" {
+		if head, err := git.NewRepo("").Head(); err == nil {
+			result.Reviewed = head
+		}
+	}
+
+	// Record in history
+	model := review.ReviewModel(result, review.BackendModel(backend))
+	if !reviewNoSave && config.Exists() {
+		entry := history.NewEntry(result, packName, review.BackendName(backend), model)
+		entry.Reviewed = result.Reviewed
+		if err := history.Record(entry); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not record review history: %v\n", err)
+		}
+	}
+
+	return &reviewRun{
+		result:   result,
+		packName: packName,
+		experts:  len(inputs),
+		members:  members,
+		backend:  review.BackendName(backend),
+		model:    model,
+		sub:      sub,
+	}, nil
+}
+
+// printReview writes the result to stdout in the format chosen by --json
+// and --output.
+func printReview(run *reviewRun) error {
+	result, packName, sub := run.result, run.packName, run.sub
 
-	// Output
 	if reviewOutput == "github-pr" {
 		var dp *review.DiffPosition
 		if sub.Content != "" {
 			dp = review.NewDiffPosition(sub.Content)
 		}
-		output := review.FormatGitHubReview(result, packName, len(inputs), dp)
+		output := review.FormatGitHubReview(result, packName, run.experts, dp)
 		data, err := review.FormatGitHubJSON(output)
 		if err != nil {
 			return fmt.Errorf("failed to marshal github review: %w", err)
 		}
 		fmt.Println(string(data))
+	} else if reviewOutput == "gitlab-mr" {
+		var dp *review.DiffPosition
+		if sub.Content != "" {
+			dp = review.NewDiffPosition(sub.Content)
+		}
+		output := review.FormatGitLabReview(result, packName, run.experts, dp, gitlabSHAs())
+		if reviewQuality != "" {
+			report, err := review.FormatCodeQualityJSON(output.CodeQuality)
+			if err != nil {
+				return fmt.Errorf("failed to marshal code quality report: %w", err)
+			}
+			if err := os.WriteFile(reviewQuality, report, 0644); err != nil {
+				return fmt.Errorf("failed to write code quality report: %w", err)
+			}
+		}
+		data, err := review.FormatGitLabJSON(output)
+		if err != nil {
+			return fmt.Errorf("failed to marshal gitlab review: %w", err)
+		}
+		fmt.Println(string(data))
+	} else if reviewOutput == "sarif" {
+		log := review.FormatSARIF(result, run.members, review.SARIFMeta{
+			Pack:        packName,
+			Backend:     run.backend,
+			Model:       run.model,
+			ToolVersion: version,
+		})
+		data, err := review.FormatSARIFJSON(log)
+		if err != nil {
+			return fmt.Errorf("failed to marshal sarif log: %w", err)
+		}
+		fmt.Println(string(data))
 	} else if reviewJSON {
 		data, err := review.FormatJSON(result)
 		if err != nil {
@@ -129,7 +526,7 @@ func runReview(cmd *cobra.Command) error {
 		}
 		fmt.Println(string(data))
 	} else {
-		fmt.Print(review.FormatHuman(result, packName, len(inputs)))
+		fmt.Print(review.FormatHuman(result, packName, run.experts))
 	}
 
 	return nil
@@ -139,38 +536,13 @@ func runReview(cmd *cobra.Command) error {
 func resolveReviewExperts() ([]review.ExpertInput, string, error) {
 	// --expert: single expert
 	if reviewExpert != "" {
-		e, err := expert.Load(reviewExpert)
-		if err != nil {
-			return nil, "", fmt.Errorf("expert '%s' not found: %w", reviewExpert, err)
-		}
-		return []review.ExpertInput{{Expert: e, Blocking: false}}, "", nil
+		inputs, err := expertInputs([]string{reviewExpert})
+		return inputs, "", err
 	}
 
 	// --pack: resolve pack members
 	if reviewPack != "" {
-		p, err := pack.Get(reviewPack)
-		if err != nil {
-			return nil, "", fmt.Errorf("pack '%s' not found: %w", reviewPack, err)
-		}
-
-		available, err := expert.List()
-		if err != nil {
-			return nil, "", fmt.Errorf("failed to list experts: %w", err)
-		}
-
-		resolved, warnings := pack.Resolve(p, available)
-		for _, w := range warnings {
-			fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
-		}
-
-		inputs := make([]review.ExpertInput, len(resolved))
-		for i, rm := range resolved {
-			inputs[i] = review.ExpertInput{
-				Expert:   rm.Expert,
-				Blocking: rm.Blocking,
-			}
-		}
-		return inputs, p.Name, nil
+		return packInputs(reviewPack)
 	}
 
 	// Default: all council experts
@@ -186,68 +558,526 @@ func resolveReviewExperts() ([]review.ExpertInput, string, error) {
 	return inputs, "", nil
 }
 
-// readSubmission reads the review content from --file or stdin.
-func readSubmission() (review.Submission, error) {
+// packInputs resolves a pack's members to review inputs.
+func packInputs(name string) ([]review.ExpertInput, string, error) {
+	p, err := pack.Get(name)
+	if err != nil {
+		return nil, "", fmt.Errorf("pack '%s' not found: %w", name, err)
+	}
+
+	available, err := expert.List()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list experts: %w", err)
+	}
+
+	resolved, warnings := pack.Resolve(p, available)
+	for _, w := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+	}
+
+	inputs := make([]review.ExpertInput, len(resolved))
+	for i, rm := range resolved {
+		inputs[i] = review.ExpertInput{
+			Expert:   rm.Expert,
+			Blocking: rm.Blocking,
+			Backend:  rm.Backend,
+		}
+	}
+	return inputs, p.Name, nil
+}
+
+// packPolicy returns the verdict policy a pack declares, or the zero
+// (strict) policy when it declares none or no pack is used.
+func packPolicy(name string) (review.Policy, error) {
+	if name == "" {
+		return review.Policy{}, nil
+	}
+	p, err := pack.Get(name)
+	if err != nil || p.Policy == nil {
+		return review.Policy{}, err
+	}
+	if err := p.Policy.Validate(); err != nil {
+		return review.Policy{}, fmt.Errorf("pack '%s': %w", name, err)
+	}
+	return *p.Policy, nil
+}
+
+// expertInputs loads experts by ID as non-blocking review inputs, falling
+// back to the suggestion bank like pack members do.
+func expertInputs(ids []string) ([]review.ExpertInput, error) {
+	inputs := make([]review.ExpertInput, 0, len(ids))
+	for _, id := range ids {
+		e, err := expert.Load(id)
+		if err != nil {
+			if e = expert.LookupSuggestion(id); e == nil {
+				return nil, fmt.Errorf("expert '%s' not found: %w", id, err)
+			}
+		}
+		inputs = append(inputs, review.ExpertInput{Expert: e, Blocking: false})
+	}
+	return inputs, nil
+}
+
+// routing is a diff split across councils by .council/routes.yaml.
+type routing struct {
+	groups   []review.RouteGroup
+	skipped  []review.FileDiff    // files too large for the token budget
+	filtered []review.SkippedFile // files left out by a routed pack's review: rules
+}
+
+// routeGroups splits a diff by .council/routes.yaml, assigning each file to
+// the council of its matching route; unmatched files go to defaults. A
+// routed pack's review: rules apply to the files routed to it. Routing
+// applies only to diffs, when the file exists, neither --pack nor --expert
+// was given and some route matches a file of the diff. Returns nil otherwise.
+func routeGroups(cfg *config.Config, sub review.Submission, defaults []review.ExpertInput) (*routing, error) {
+	if reviewPack != "" || reviewExpert != "" || !review.IsDiff(sub.Content) || !config.Exists() {
+		return nil, nil
+	}
+
+	routes, err := review.LoadRoutes(config.Path(config.RoutesFile))
+	if err != nil || routes == nil {
+		return nil, err
+	}
+	if !slices.ContainsFunc(review.DiffPaths(sub.Content), func(p string) bool { return routes.Match(p) != nil }) {
+		return nil, nil
+	}
+
+	chunks := review.SplitDiff(sub.Content, review.ChunkOptions{
+		TokenBudget:    reviewBudget,
+		PromptOverhead: review.EstimatePromptOverhead(defaults),
+	})
+
+	rt := &routing{skipped: chunks.Skipped}
+	index := make(map[string]int)
+	rules := make(map[string]review.PathRules)
+	for _, f := range chunks.Files {
+		route := routes.Match(f.Path)
+		council := review.DefaultCouncil
+		if route != nil {
+			council = route.Council()
+		}
+
+		i, ok := index[council]
+		if !ok {
+			inputs := defaults
+			var policy review.Policy
+			if route != nil && route.Pack != "" {
+				inputs, _, err = packInputs(route.Pack)
+				if err == nil {
+					policy, err = packPolicy(route.Pack)
+				}
+				rules[council] = reviewPathRules(cfg, route.Pack)
+			} else if route != nil {
+				inputs, err = expertInputs(route.Experts)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", config.RoutesFile, err)
+			}
+			i = len(rt.groups)
+			index[council] = i
+			rt.groups = append(rt.groups, review.RouteGroup{Council: council, Inputs: inputs, Policy: policy})
+		}
+
+		if reason := rules[council].Exclude(f.Path); reason != "" {
+			rt.filtered = append(rt.filtered, review.SkippedFile{Path: f.Path, Reason: reason + " (pack " + council + ")"})
+			continue
+		}
+		rt.groups[i].Files = append(rt.groups[i].Files, f)
+	}
+
+	// Drop councils whose every file was filtered out
+	rt.groups = slices.DeleteFunc(rt.groups, func(g review.RouteGroup) bool { return len(g.Files) == 0 })
+	if len(rt.groups) == 0 && len(rt.skipped) == 0 {
+		return nil, fmt.Errorf("%w: all %d routed file(s) were filtered out by their pack's review rules", errNoChanges, len(rt.filtered))
+	}
+	return rt, nil
+}
+
+// readSubmission reads the review content from git, --file or stdin.
+// Diffs are filtered through rules; the files removed are returned so the
+// output can list them. A since commit replaces the merge base of --base.
+func readSubmission(rules review.PathRules, since string) (review.Submission, []review.SkippedFile, error) {
+	sel, useGit, err := gitSelection()
+	if err != nil {
+		return review.Submission{}, nil, err
+	}
+	if useGit {
+		if since != "" {
+			sel.Base = since
+		}
+		return gitSubmission(sel, rules)
+	}
+
 	if reviewFile != "" {
 		data, err := os.ReadFile(reviewFile)
 		if err != nil {
-			return review.Submission{}, fmt.Errorf("failed to read file: %w", err)
+			return review.Submission{}, nil, fmt.Errorf("failed to read file: %w", err)
+		}
+
+		content := string(data)
+		if !review.IsDiff(content) {
+			if reason := rules.Exclude(filepath.ToSlash(filepath.Clean(reviewFile))); reason != "" {
+				return review.Submission{}, nil, fmt.Errorf("%s is excluded from review: %s", reviewFile, reason)
+			}
+			return review.Submission{
+				Content: content,
+				Context: fmt.Sprintf("File: %s", reviewFile),
+			}, nil, nil
+		}
+
+		content, filtered, err := filterSubmission(content, rules.Exclude)
+		if err != nil {
+			return review.Submission{}, nil, err
 		}
 		return review.Submission{
-			Content: string(data),
+			Content: content,
 			Context: fmt.Sprintf("File: %s", reviewFile),
-		}, nil
+		}, filtered, nil
 	}
 
 	// Read from stdin
 	info, _ := os.Stdin.Stat()
 	if info.Mode()&os.ModeCharDevice != 0 {
-		return review.Submission{}, fmt.Errorf("no input: pipe a diff or use --file\n\nExamples:\n  git diff main | council review --pack rails\n  council review --pack rails --file src/main.go")
+		return review.Submission{}, nil, fmt.Errorf("no input: pipe a diff or use --file\n\nExamples:\n  git diff main | council review --pack rails\n  council review --pack rails --file src/main.go")
 	}
 
 	data, err := io.ReadAll(os.Stdin)
 	if err != nil {
-		return review.Submission{}, fmt.Errorf("failed to read stdin: %w", err)
+		return review.Submission{}, nil, fmt.Errorf("failed to read stdin: %w", err)
 	}
 
 	content := string(data)
 	if content == "" {
-		return review.Submission{}, fmt.Errorf("empty input from stdin")
+		return review.Submission{}, nil, fmt.Errorf("empty input from stdin")
 	}
 
-	return review.Submission{Content: content}, nil
+	if !review.IsDiff(content) {
+		return review.Submission{Content: content}, nil, nil
+	}
+	content, filtered, err := filterSubmission(content, rules.Exclude)
+	if err != nil {
+		return review.Submission{}, nil, err
+	}
+	return review.Submission{Content: content}, filtered, nil
 }
 
-// buildBackend creates the appropriate review backend based on config and environment.
-// CLI flags (--backend, --provider, --model) override config values.
-func buildBackend(cfg *config.Config) (review.Backend, error) {
-	// Copy config to avoid mutating the caller's struct
-	overridden := *cfg
-	if reviewBackend != "" {
-		overridden.AI.Backend = reviewBackend
+// errNoChanges reports a diff that is empty, or empty once filtered.
+var errNoChanges = errors.New("no changes to review")
+
+// errNothingNew reports an incremental review with nothing to review since
+// the last one. It wraps errNoChanges.
+var errNothingNew = fmt.Errorf("%w: nothing new", errNoChanges)
+
+// filterSubmission removes excluded files from a diff and fails when
+// nothing is left to review.
+func filterSubmission(diff string, exclude func(path string) string) (string, []review.SkippedFile, error) {
+	kept, filtered := review.FilterDiff(diff, exclude)
+	if strings.TrimSpace(kept) == "" {
+		return "", nil, fmt.Errorf("%w: all %d changed file(s) were filtered out (see review.ignore / review.include)", errNoChanges, len(filtered))
 	}
-	if reviewProvider != "" {
-		overridden.AI.Provider = reviewProvider
+	return kept, filtered, nil
+}
+
+// reviewPathRules combines review.ignore / review.include from config.yaml
+// with the overrides of the selected pack.
+func reviewPathRules(cfg *config.Config, packName string) review.PathRules {
+	rules := cfg.Review
+	if packName != "" {
+		if p, err := pack.Get(packName); err == nil {
+			rules = rules.Merge(p.Review)
+		}
 	}
-	if reviewModel != "" {
-		overridden.AI.Model = reviewModel
+	return review.PathRules{Ignore: rules.Ignore, Include: rules.Include}
+}
+
+// gitlabSHAs returns the diff refs that anchor GitLab discussions. GitLab
+// CI provides them for merge request pipelines; elsewhere HEAD is the head
+// and, with --base, its merge base with HEAD is the base. The start SHA
+// falls back to the base, which matches the MR diff until the target
+// branch moves on.
+func gitlabSHAs() review.GitLabSHAs {
+	shas := review.GitLabSHAs{
+		Base:  os.Getenv("CI_MERGE_REQUEST_DIFF_BASE_SHA"),
+		Start: os.Getenv("CI_MERGE_REQUEST_TARGET_BRANCH_SHA"),
+		Head:  os.Getenv("CI_COMMIT_SHA"),
 	}
 
-	backend, provider, model := overridden.DetectBackend()
+	repo := git.NewRepo("")
+	if shas.Head == "" {
+		shas.Head, _ = repo.Head()
+	}
+	if shas.Base == "" && reviewBase != "" {
+		shas.Base, _ = repo.MergeBase(reviewBase, "HEAD")
+	}
+	if shas.Start == "" {
+		shas.Start = shas.Base
+	}
+	return shas
+}
+
+// gitSelection builds a git.Selection from --base, --staged, --commit and
+// --range. At most one may be set, and none may be combined with --file.
+func gitSelection() (git.Selection, bool, error) {
+	sel := git.Selection{
+		Base:   reviewBase,
+		Staged: reviewStaged,
+		Commit: reviewCommit,
+		Range:  reviewRange,
+	}
+
+	set := 0
+	for _, on := range []bool{sel.Base != "", sel.Staged, sel.Commit != "", sel.Range != ""} {
+		if on {
+			set++
+		}
+	}
+
+	switch {
+	case set == 0:
+		return sel, false, nil
+	case set > 1:
+		return sel, false, fmt.Errorf("use only one of --base, --staged, --commit, --range")
+	case reviewFile != "":
+		return sel, false, fmt.Errorf("--file cannot be combined with --base, --staged, --commit or --range")
+	}
+	return sel, true, nil
+}
+
+// incrementalBase finds the commit --incremental continues from and the
+// review that covered it, whose findings are carried forward. On a pull
+// request in GitHub Actions, where the review history isn't kept between
+// runs, it is the latest earlier council review of the pull request that
+// recorded a commit, with the findings it embedded. Elsewhere it is the
+// latest recorded review of this branch and pack. Either way HEAD must
+// still build on the commit. Returns "", for a full review, without
+// --incremental or when there is none.
+func incrementalBase(ctx context.Context, packName string) (string, *review.SynthesizedResult, error) {
+	if !reviewIncr {
+		return "", nil, nil
+	}
+	if reviewBase == "" {
+		return "", nil, fmt.Errorf("--incremental requires --base")
+	}
+
+	repo := git.NewRepo("")
+	reachable := func(sha string) bool {
+		return repo.IsAncestor(sha, "HEAD")
+	}
 
-	switch backend {
-	case "api":
-		if provider == "" {
-			return nil, fmt.Errorf("api backend requires a provider (anthropic, openai, ollama, github)")
+	if pr, ok := actionsPullRequest(); ok {
+		token := githubToken()
+		if token == "" {
+			return "", nil, fmt.Errorf("--incremental on a pull request reads its earlier council reviews: set GITHUB_TOKEN or GH_TOKEN")
 		}
-		return review.NewAPIBackend(provider, model)
-	case "cli":
-		aiCmd, err := cfg.DetectAICommand()
+		earlier, err := github.NewClient(os.Getenv("GITHUB_API_URL"), token).EarlierReviews(ctx, pr.Repo, pr.Number)
 		if err != nil {
-			return nil, err
+			return "", nil, fmt.Errorf("failed to read earlier reviews of %s#%d: %w", pr.Repo, pr.Number, err)
+		}
+		for _, e := range earlier {
+			if !reachable(e.Commit) {
+				continue
+			}
+			// Without its findings the review can't be continued: the
+			// new one would supersede it and drop them from the verdict
+			if e.Result == nil {
+				fmt.Fprintln(os.Stderr, "The last council review of this pull request has too many findings to carry forward; reviewing all changes since the base.")
+				return "", nil, nil
+			}
+			fmt.Fprintf(os.Stderr, "Incremental review since %s (the last council review of the pull request).\n", e.Commit[:min(8, len(e.Commit))])
+			return e.Commit, e.Result, nil
+		}
+		fmt.Fprintln(os.Stderr, "No earlier review of this pull request to continue from; reviewing all changes since the base.")
+		return "", nil, nil
+	}
+
+	if !config.Exists() {
+		return "", nil, fmt.Errorf("--incremental needs the review history in .council/: run 'council init' first")
+	}
+	branch, err := repo.Branch()
+	if err != nil {
+		return "", nil, err
+	}
+	if branch == "" {
+		return "", nil, fmt.Errorf("--incremental continues from the last review of the current branch, but HEAD is detached: check out a branch")
+	}
+	prev, err := history.LastReviewed(branch, packName, reachable)
+	if err != nil {
+		return "", nil, err
+	}
+	if prev == nil {
+		fmt.Fprintln(os.Stderr, "No earlier review of this branch to continue from; reviewing all changes since the base.")
+		return "", nil, nil
+	}
+	fmt.Fprintf(os.Stderr, "Incremental review since %s (review %s).\n", prev.ShortHead(), prev.ID)
+	return prev.Reviewed, prev.Result, nil
+}
+
+// gitSubmission reads a diff from git, drops files .gitattributes marks as
+// generated or vendored or that rules exclude, and adds the branch and
+// commit messages as context.
+func gitSubmission(sel git.Selection, rules review.PathRules) (review.Submission, []review.SkippedFile, error) {
+	repo := git.NewRepo("")
+
+	change, err := repo.Changes(sel)
+	if err != nil {
+		return review.Submission{}, nil, err
+	}
+	if strings.TrimSpace(change.Diff) == "" {
+		return review.Submission{}, nil, errNoChanges
+	}
+
+	linguist, err := repo.LinguistExcluded(review.DiffPaths(change.Diff))
+	if err != nil {
+		return review.Submission{}, nil, err
+	}
+	diff, filtered, err := filterSubmission(change.Diff, func(path string) string {
+		if attr, ok := linguist[path]; ok {
+			return "marked " + attr + " in .gitattributes"
+		}
+		return rules.Exclude(path)
+	})
+	if err != nil {
+		return review.Submission{}, nil, err
+	}
+
+	return review.Submission{
+		Content: diff,
+		Context: gitContext(change),
+	}, filtered, nil
+}
+
+// gitContext renders the branch and commit messages for Submission.Context.
+func gitContext(c *git.Change) string {
+	var b strings.Builder
+	if c.Branch != "" {
+		fmt.Fprintf(&b, "Branch: %s\n", c.Branch)
+	}
+	if len(c.Messages) > 0 {
+		if b.Len() > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString("Commit messages:\n")
+		for _, msg := range c.Messages {
+			fmt.Fprintf(&b, "\n%s\n", msg)
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// perFileChunks splits the submission for per-file review when --per-file is
+// set or the diff plus prompt overhead exceeds the token budget.
+// Returns ok=false when the submission should be reviewed as a whole.
+func perFileChunks(sub review.Submission, inputs []review.ExpertInput) (review.ChunkResult, bool, error) {
+	if !review.IsDiff(sub.Content) {
+		if reviewPerFile {
+			return review.ChunkResult{}, false, fmt.Errorf("--per-file requires a unified diff (e.g. git diff main | council review --per-file)")
+		}
+		return review.ChunkResult{}, false, nil
+	}
+
+	opts := review.ChunkOptions{
+		TokenBudget:    reviewBudget,
+		PromptOverhead: review.EstimatePromptOverhead(inputs),
+	}
+
+	budget := opts.TokenBudget
+	if budget <= 0 {
+		budget = review.DefaultTokenBudget
+	}
+	if !reviewPerFile && review.EstimateTokens(sub.Content)+opts.PromptOverhead <= budget {
+		return review.ChunkResult{}, false, nil
+	}
+
+	return review.SplitDiff(sub.Content, opts), true, nil
+}
+
+// checkBudgeted refuses --max-cost when the budget couldn't price a single
+// call of the default backend, and warns about the fallbacks and expert
+// backends it can't cap.
+func checkBudgeted(runner *review.Runner, inputs []review.ExpertInput) error {
+	primary := runner.Backend
+	if chain, ok := primary.(*review.FallbackBackend); ok {
+		primary = chain.Backends[0]
+	}
+	if unpriced := review.Unpriced(primary); len(unpriced) > 0 {
+		return fmt.Errorf("--max-cost can't cap %s: its cost can't be estimated (CLI backends report no usage, and the model isn't in the price table)", unpriced[0])
+	}
+
+	var unpriced []string
+	for _, name := range review.Unpriced(runner.Backend) {
+		if !slices.Contains(unpriced, name) {
+			unpriced = append(unpriced, name)
+		}
+	}
+	for _, inp := range inputs {
+		if spec := inp.BackendSpec(); !spec.IsZero() {
+			if b, err := runner.Backends.Get(spec); err == nil {
+				for _, name := range review.Unpriced(b) {
+					if !slices.Contains(unpriced, name) {
+						unpriced = append(unpriced, name)
+					}
+				}
+			}
+		}
+	}
+	for _, name := range unpriced {
+		fmt.Fprintf(os.Stderr, "Warning: --max-cost doesn't cap calls served by %s: their cost can't be estimated\n", name)
+	}
+	return nil
+}
+
+// newProgressPrinter returns a ProgressFunc that writes one line per expert
+// as it finishes or fails. When live is set (stderr is a terminal), streamed
+// bytes are shown on a single line that is redrawn in place.
+func newProgressPrinter(w io.Writer, live bool) review.ProgressFunc {
+	var mu sync.Mutex
+	received := make(map[string]int)
+	streaming := false
+
+	return func(ev review.ProgressEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		clearLine := func() {
+			if streaming {
+				_, _ = fmt.Fprint(w, "\r\033[K")
+				streaming = false
+			}
+		}
+
+		name := ev.Expert
+		if ev.Round > 1 {
+			name = fmt.Sprintf("%s (round %d)", ev.Expert, ev.Round)
+		}
+
+		switch ev.Kind {
+		case review.ProgressStreaming:
+			if !live {
+				return
+			}
+			received[ev.Expert] = ev.Bytes
+			total := 0
+			for _, n := range received {
+				total += n
+			}
+			_, _ = fmt.Fprintf(w, "\r\033[K  receiving from %d call(s)... %.1f KB", len(received), float64(total)/1024)
+			streaming = true
+		case review.ProgressFinished:
+			clearLine()
+			delete(received, ev.Expert)
+			delete(received, review.CollectiveLabel)
+			_, _ = fmt.Fprintf(w, "  ✓ %s: %s\n", name, ev.Verdict)
+		case review.ProgressRetrying:
+			clearLine()
+			_, _ = fmt.Fprintf(w, "  ↻ %s: %v, retrying\n", name, ev.Err)
+		case review.ProgressFallback:
+			clearLine()
+			_, _ = fmt.Fprintf(w, "  ↪ %s: %v, falling back to %s\n", name, ev.Err, ev.Backend)
+		case review.ProgressFailed:
+			clearLine()
+			delete(received, ev.Expert)
+			_, _ = fmt.Fprintf(w, "  ✗ %s: %v\n", name, ev.Err)
 		}
-		return review.NewCLIBackend(aiCmd, cfg.AI.Args), nil
-	default:
-		return nil, fmt.Errorf("no backend available\n\nInstall an AI CLI (claude, opencode) or set an API key (ANTHROPIC_API_KEY, OPENAI_API_KEY, GITHUB_TOKEN)")
 	}
 }
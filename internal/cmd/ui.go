@@ -17,6 +17,15 @@ func isInteractive() bool {
 	return (fi.Mode() & os.ModeCharDevice) != 0
 }
 
+// isTerminal returns true if f is attached to a terminal.
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return (fi.Mode() & os.ModeCharDevice) != 0
+}
+
 // Confirm asks user for confirmation with a y/n prompt
 func Confirm(prompt string) bool {
 	fmt.Print(prompt + " [Y/n] ")
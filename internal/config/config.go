@@ -16,25 +16,112 @@ const (
 	ExpertsDir  = "experts"
 	CommandsDir = "commands"
 	PacksDir    = "packs"
+	CacheDir    = "cache"
+	HistoryDir  = "history"
+	RoutesFile  = "routes.yaml"
 )
 
 // Config represents the council configuration
 type Config struct {
-	Version int      `yaml:"version"`
-	Tool    string   `yaml:"tool,omitempty"` // Primary tool: "claude", "opencode", "generic"
-	AI      AIConfig `yaml:"ai"`
-	Targets []string `yaml:"targets,omitempty"` // Optional: override sync targets
+	Version int            `yaml:"version"`
+	Tool    string         `yaml:"tool,omitempty"` // Primary tool: "claude", "opencode", "generic"
+	AI      AIConfig       `yaml:"ai"`
+	Targets []string       `yaml:"targets,omitempty"` // Optional: override sync targets
+	Review  ReviewConfig   `yaml:"review,omitempty"`
+	Domains []DomainConfig `yaml:"domains,omitempty"` // Optional: decision hierarchy, highest priority first
+}
+
+// DomainConfig is one domain of the decision hierarchy. Experts whose focus
+// mentions one of the keywords belong to it, unless their frontmatter sets
+// domain: explicitly. A built-in domain (security, product, scope,
+// convention, performance, quality) listed without keywords keeps its
+// built-in ones, so a bare name is enough:
+//
+//	domains:
+//	  - security
+//	  - name: compliance
+//	    keywords: [gdpr, hipaa, audit, pii]
+//	  - product
+//	  - quality
+type DomainConfig struct {
+	Name     string   `yaml:"name" json:"name"`
+	Keywords []string `yaml:"keywords,omitempty" json:"keywords,omitempty"`
+}
+
+// UnmarshalYAML accepts a bare domain name as well as the full mapping.
+func (d *DomainConfig) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*d = DomainConfig{Name: node.Value}
+		return nil
+	}
+	type plain DomainConfig
+	return node.Decode((*plain)(d))
+}
+
+// ReviewConfig selects which files of a diff are reviewed.
+// Patterns are gitignore-style globs: "go.sum", "*.snap", "vendor/",
+// "db/migrate/**".
+type ReviewConfig struct {
+	Ignore  []string `yaml:"ignore,omitempty" json:"ignore,omitempty"`   // files matching any pattern are left out
+	Include []string `yaml:"include,omitempty" json:"include,omitempty"` // if set, only matching files are reviewed
+}
+
+// Merge returns r with any list set in override replacing r's own.
+func (r ReviewConfig) Merge(override *ReviewConfig) ReviewConfig {
+	if override == nil {
+		return r
+	}
+	if len(override.Ignore) > 0 {
+		r.Ignore = override.Ignore
+	}
+	if len(override.Include) > 0 {
+		r.Include = override.Include
+	}
+	return r
 }
 
 // AIConfig holds AI configuration for reviews.
 type AIConfig struct {
-	Command     string   `yaml:"command,omitempty"`
-	Args        []string `yaml:"args,omitempty"`
-	Backend     string   `yaml:"backend,omitempty"`     // "cli" or "api"
-	Provider    string   `yaml:"provider,omitempty"`     // "anthropic", "openai", "ollama"
-	Model       string   `yaml:"model,omitempty"`        // e.g. "claude-sonnet-4-6", "gpt-4o"
-	Timeout     int      `yaml:"timeout"`
-	Concurrency int      `yaml:"concurrency,omitempty"`
+	Command     string           `yaml:"command,omitempty"`
+	Args        []string         `yaml:"args,omitempty"`
+	Backend     string           `yaml:"backend,omitempty"`  // "cli" or "api"
+	Provider    string           `yaml:"provider,omitempty"` // "anthropic", "openai", "ollama"
+	Model       string           `yaml:"model,omitempty"`    // e.g. "claude-sonnet-4-6", "gpt-4o"
+	Timeout     int              `yaml:"timeout"`
+	Concurrency int              `yaml:"concurrency,omitempty"`
+	Fallbacks   []FallbackConfig `yaml:"fallbacks,omitempty"` // tried in order when the backend above fails
+}
+
+// FallbackConfig is one entry of the ai.fallbacks chain: a backend to try
+// when the ones before it fail. A bare name is a provider, or "cli" for the
+// AI CLI:
+//
+//	ai:
+//	  provider: anthropic
+//	  fallbacks:
+//	    - openai
+//	    - provider: ollama
+//	      model: qwen2.5-coder
+//	    - cli
+type FallbackConfig struct {
+	Backend  string `yaml:"backend,omitempty"`  // "cli" or "api"; "api" when Provider is set
+	Provider string `yaml:"provider,omitempty"` // "anthropic", "openai", "ollama", "github"
+	Model    string `yaml:"model,omitempty"`    // the provider's default model when empty
+}
+
+// UnmarshalYAML accepts a bare provider name, or "cli", as well as the full
+// mapping.
+func (f *FallbackConfig) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		if node.Value == "cli" {
+			*f = FallbackConfig{Backend: "cli"}
+		} else {
+			*f = FallbackConfig{Provider: node.Value}
+		}
+		return nil
+	}
+	type plain FallbackConfig
+	return node.Decode((*plain)(f))
 }
 
 // ValidBackends is the set of recognized backend values.
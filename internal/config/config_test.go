@@ -3,7 +3,10 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
+
+	"gopkg.in/yaml.v3"
 )
 
 func TestDefault(t *testing.T) {
@@ -359,3 +362,59 @@ func TestConfigToolFieldPersistence(t *testing.T) {
 		t.Errorf("Load().Tool = %q, want claude", loaded.Tool)
 	}
 }
+
+func TestReviewConfigMerge(t *testing.T) {
+	base := ReviewConfig{Ignore: []string{"go.sum"}, Include: []string{"app/"}}
+
+	if got := base.Merge(nil); !reflect.DeepEqual(got, base) {
+		t.Errorf("Merge(nil) = %+v, want %+v", got, base)
+	}
+
+	got := base.Merge(&ReviewConfig{Ignore: []string{"vendor/"}})
+	want := ReviewConfig{Ignore: []string{"vendor/"}, Include: []string{"app/"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Merge() = %+v, want %+v", got, want)
+	}
+}
+
+func TestDomainConfigUnmarshal(t *testing.T) {
+	data := []byte(`domains:
+  - security
+  - name: compliance
+    keywords: [gdpr, audit]
+`)
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	want := []DomainConfig{
+		{Name: "security"},
+		{Name: "compliance", Keywords: []string{"gdpr", "audit"}},
+	}
+	if !reflect.DeepEqual(cfg.Domains, want) {
+		t.Errorf("Domains = %+v, want %+v", cfg.Domains, want)
+	}
+}
+
+func TestFallbackConfigUnmarshal(t *testing.T) {
+	data := []byte(`fallbacks:
+  - openai
+  - provider: ollama
+    model: llama3
+  - cli
+`)
+	var ai AIConfig
+	if err := yaml.Unmarshal(data, &ai); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	want := []FallbackConfig{
+		{Provider: "openai"},
+		{Provider: "ollama", Model: "llama3"},
+		{Backend: "cli"},
+	}
+	if !reflect.DeepEqual(ai.Fallbacks, want) {
+		t.Errorf("Fallbacks = %+v, want %+v", ai.Fallbacks, want)
+	}
+}
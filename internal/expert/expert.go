@@ -100,6 +100,7 @@ type Expert struct {
 	ID         string    `yaml:"id" json:"id"`
 	Name       string    `yaml:"name" json:"name"`
 	Focus      string    `yaml:"focus" json:"focus"`
+	Domain     string    `yaml:"domain,omitempty" json:"domain,omitempty"` // review domain, e.g. "security"; guessed from Focus when empty
 	Influences []string  `yaml:"influences,omitempty" json:"influences,omitempty"`
 	Backstory  string    `yaml:"backstory,omitempty" json:"backstory,omitempty"`
 	Philosophy string    `yaml:"philosophy,omitempty" json:"philosophy,omitempty"`
@@ -111,6 +112,11 @@ type Expert struct {
 	Core     bool     `yaml:"core,omitempty" json:"-"`     // Always suggest for matching intention
 	Triggers []string `yaml:"triggers,omitempty" json:"-"` // Only suggest when patterns detected
 
+	// Review backend overrides; empty fields use the configured backend
+	Backend  string `yaml:"backend,omitempty" json:"backend,omitempty"`   // "cli" or "api"
+	Provider string `yaml:"provider,omitempty" json:"provider,omitempty"` // e.g. "anthropic", "ollama"
+	Model    string `yaml:"model,omitempty" json:"model,omitempty"`       // e.g. "claude-sonnet-4-6"
+
 	// Personal council metadata (used by creator commands)
 	Category string `yaml:"category,omitempty" json:"category,omitempty"` // e.g., "custom", "rails", "go"
 	Priority string `yaml:"priority,omitempty" json:"priority,omitempty"` // "always", "high", "normal"
@@ -0,0 +1,192 @@
+// Package git runs the read-only git commands council needs to describe
+// and diff the working tree it is reviewing.
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Repo runs git commands in a working tree.
+type Repo struct {
+	Dir string // working directory; "" means the current directory
+}
+
+// NewRepo creates a Repo for the given directory.
+func NewRepo(dir string) *Repo {
+	return &Repo{Dir: dir}
+}
+
+// Head returns the full SHA of HEAD.
+func (r *Repo) Head() (string, error) {
+	return r.output("rev-parse", "HEAD")
+}
+
+// Branch returns the current branch name, or "" on a detached HEAD.
+func (r *Repo) Branch() (string, error) {
+	branch, err := r.output("rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	if branch == "HEAD" {
+		return "", nil
+	}
+	return branch, nil
+}
+
+// HooksDir returns the directory git runs hooks from, honoring core.hooksPath.
+func (r *Repo) HooksDir() (string, error) {
+	dir, err := r.output("rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", err
+	}
+	if !filepath.IsAbs(dir) && r.Dir != "" {
+		dir = filepath.Join(r.Dir, dir)
+	}
+	return dir, nil
+}
+
+// RemoteDefault returns the default branch of origin (e.g. "origin/main"),
+// as recorded by clone or "git remote set-head".
+func (r *Repo) RemoteDefault() (string, error) {
+	return r.output("rev-parse", "--abbrev-ref", "origin/HEAD")
+}
+
+// MergeBase returns the full SHA of the best common ancestor of a and b.
+func (r *Repo) MergeBase(a, b string) (string, error) {
+	return r.output("merge-base", a, b)
+}
+
+// IsAncestor reports whether commit a is an ancestor of b, or b itself. A
+// commit git doesn't know, e.g. one dropped by a force-push and since
+// garbage collected, is not.
+func (r *Repo) IsAncestor(a, b string) bool {
+	_, err := r.run("merge-base", "--is-ancestor", a, b)
+	return err == nil
+}
+
+// Selection chooses which changes to review. Exactly one field should be set.
+type Selection struct {
+	Base   string // changes since the merge base with this ref, including uncommitted work
+	Staged bool   // changes staged in the index
+	Commit string // the changes introduced by a single commit
+	Range  string // a revision range, "a..b" or "a...b"
+}
+
+// Change is a diff plus the metadata that explains it.
+type Change struct {
+	Diff     string
+	Branch   string
+	Messages []string // commit messages covered by the diff, oldest first
+}
+
+// diffFlags keep diff output plain and parseable regardless of user config.
+var diffFlags = []string{"--no-color", "--no-ext-diff"}
+
+// Changes produces the diff and commit messages for a selection.
+func (r *Repo) Changes(sel Selection) (*Change, error) {
+	c := &Change{}
+	c.Branch, _ = r.Branch()
+
+	var diffArgs []string
+	logRange := ""
+
+	switch {
+	case sel.Staged:
+		diffArgs = append([]string{"diff", "--cached"}, diffFlags...)
+	case sel.Commit != "":
+		diffArgs = append([]string{"diff-tree", "-p", "--root", "-m", "--first-parent", "--no-commit-id"}, diffFlags...)
+		diffArgs = append(diffArgs, sel.Commit)
+		msg, err := r.output("log", "-1", "--format=%B", sel.Commit)
+		if err != nil {
+			return nil, err
+		}
+		c.Messages = []string{msg}
+	case sel.Range != "":
+		diffArgs = append(append([]string{"diff"}, diffFlags...), sel.Range)
+		logRange = sel.Range
+	case sel.Base != "":
+		mergeBase, err := r.output("merge-base", sel.Base, "HEAD")
+		if err != nil {
+			return nil, err
+		}
+		diffArgs = append(append([]string{"diff"}, diffFlags...), mergeBase)
+		logRange = mergeBase + "..HEAD"
+	default:
+		return nil, fmt.Errorf("no changes selected")
+	}
+
+	diff, err := r.run(diffArgs...)
+	if err != nil {
+		return nil, err
+	}
+	c.Diff = diff
+
+	if logRange != "" {
+		log, err := r.output("log", "--reverse", "--format=%B%x00", strings.Replace(logRange, "...", "..", 1))
+		if err != nil {
+			return nil, err
+		}
+		for _, msg := range strings.Split(log, "\x00") {
+			if msg = strings.TrimSpace(msg); msg != "" {
+				c.Messages = append(c.Messages, msg)
+			}
+		}
+	}
+
+	return c, nil
+}
+
+// LinguistExcluded returns the paths marked linguist-generated or
+// linguist-vendored in .gitattributes, mapped to the attribute that matched.
+func (r *Repo) LinguistExcluded(paths []string) (map[string]string, error) {
+	excluded := make(map[string]string)
+	if len(paths) == 0 {
+		return excluded, nil
+	}
+
+	args := append([]string{"check-attr", "-z", "linguist-generated", "linguist-vendored", "--"}, paths...)
+	out, err := r.run(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	// With -z, records are "path NUL attribute NUL value NUL", so paths
+	// containing ": " or newlines come through intact.
+	fields := strings.Split(strings.TrimSuffix(out, "\x00"), "\x00")
+	for i := 0; i+2 < len(fields); i += 3 {
+		path, attr, value := fields[i], fields[i+1], fields[i+2]
+		if value == "set" || value == "true" {
+			excluded[path] = attr
+		}
+	}
+	return excluded, nil
+}
+
+// output runs git with args and returns trimmed stdout.
+func (r *Repo) output(args ...string) (string, error) {
+	out, err := r.run(args...)
+	return strings.TrimSpace(out), err
+}
+
+// run executes git with args and returns stdout untouched.
+func (r *Repo) run(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = r.Dir
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		detail := strings.TrimSpace(stderr.String())
+		if detail != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], detail)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return stdout.String(), nil
+}
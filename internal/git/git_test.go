@@ -0,0 +1,208 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// initRepo creates a git repository with one commit in a temp directory.
+func initRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	gitRun(t, dir, "init", "-q", "-b", "main")
+	writeFile(t, dir, "main.go", "package main\n")
+	gitRun(t, dir, "add", ".")
+	gitRun(t, dir, "commit", "-q", "-m", "initial")
+	return dir
+}
+
+// gitRun runs git in dir with a fixed identity, failing the test on error.
+func gitRun(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+// writeFile writes content to name inside dir.
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHeadAndBranch(t *testing.T) {
+	repo := NewRepo(initRepo(t))
+
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatalf("Head() error = %v", err)
+	}
+	if len(head) != 40 {
+		t.Errorf("Head() = %q, want a 40-char SHA", head)
+	}
+
+	branch, err := repo.Branch()
+	if err != nil {
+		t.Fatalf("Branch() error = %v", err)
+	}
+	if branch != "main" {
+		t.Errorf("Branch() = %q, want main", branch)
+	}
+}
+
+func TestMergeBase(t *testing.T) {
+	dir := initRepo(t)
+	repo := NewRepo(dir)
+	base, _ := repo.Head()
+
+	gitRun(t, dir, "checkout", "-q", "-b", "feature")
+	writeFile(t, dir, "util.go", "package main\n")
+	gitRun(t, dir, "add", ".")
+	gitRun(t, dir, "commit", "-q", "-m", "Add util")
+
+	got, err := repo.MergeBase("main", "HEAD")
+	if err != nil {
+		t.Fatalf("MergeBase() error = %v", err)
+	}
+	if got != base {
+		t.Errorf("MergeBase() = %q, want %q", got, base)
+	}
+}
+
+func TestHooksDir(t *testing.T) {
+	dir := initRepo(t)
+
+	hooks, err := NewRepo(dir).HooksDir()
+	if err != nil {
+		t.Fatalf("HooksDir() error = %v", err)
+	}
+	if hooks != filepath.Join(dir, ".git", "hooks") {
+		t.Errorf("HooksDir() = %q, want .git/hooks", hooks)
+	}
+
+	gitRun(t, dir, "config", "core.hooksPath", ".githooks")
+	if hooks, _ := NewRepo(dir).HooksDir(); hooks != filepath.Join(dir, ".githooks") {
+		t.Errorf("HooksDir() with core.hooksPath = %q, want .githooks", hooks)
+	}
+}
+
+func TestNotARepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	repo := NewRepo(t.TempDir())
+	if _, err := repo.Head(); err == nil {
+		t.Error("Head() outside a repository should fail")
+	}
+}
+
+func TestChanges(t *testing.T) {
+	dir := initRepo(t)
+	gitRun(t, dir, "checkout", "-q", "-b", "feature")
+	writeFile(t, dir, "util.go", "package main\n\nfunc helper() {}\n")
+	gitRun(t, dir, "add", ".")
+	gitRun(t, dir, "commit", "-q", "-m", "Add helper")
+	writeFile(t, dir, "main.go", "package main\n\nfunc main() {}\n")
+	gitRun(t, dir, "add", ".")
+	gitRun(t, dir, "commit", "-q", "-m", "Add main")
+	writeFile(t, dir, "staged.go", "package main\n")
+	gitRun(t, dir, "add", "staged.go")
+
+	repo := NewRepo(dir)
+
+	tests := []struct {
+		name     string
+		sel      Selection
+		files    []string
+		messages []string
+	}{
+		{"base", Selection{Base: "main"}, []string{"util.go", "main.go", "staged.go"}, []string{"Add helper", "Add main"}},
+		{"staged", Selection{Staged: true}, []string{"staged.go"}, nil},
+		{"commit", Selection{Commit: "HEAD~1"}, []string{"util.go"}, []string{"Add helper"}},
+		{"range", Selection{Range: "main..feature"}, []string{"util.go", "main.go"}, []string{"Add helper", "Add main"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := repo.Changes(tt.sel)
+			if err != nil {
+				t.Fatalf("Changes() error = %v", err)
+			}
+			if c.Branch != "feature" {
+				t.Errorf("Branch = %q, want feature", c.Branch)
+			}
+			for _, f := range tt.files {
+				if !strings.Contains(c.Diff, "b/"+f) {
+					t.Errorf("diff should include %s", f)
+				}
+			}
+			if strings.Count(c.Diff, "diff --git ") != len(tt.files) {
+				t.Errorf("diff has %d files, want %d", strings.Count(c.Diff, "diff --git "), len(tt.files))
+			}
+			if strings.Join(c.Messages, "|") != strings.Join(tt.messages, "|") {
+				t.Errorf("Messages = %q, want %q", c.Messages, tt.messages)
+			}
+		})
+	}
+
+	if _, err := repo.Changes(Selection{Base: "no-such-branch"}); err == nil {
+		t.Error("Changes() with an unknown base should fail")
+	}
+}
+
+func TestLinguistExcluded(t *testing.T) {
+	dir := initRepo(t)
+	writeFile(t, dir, ".gitattributes", "*.lock linguist-generated\nvendor/** linguist-vendored=true\nkeep.lock -linguist-generated\n")
+
+	excluded, err := NewRepo(dir).LinguistExcluded([]string{"main.go", "deps.lock", "vendor/lib.go", "keep.lock", "notes: v2.lock"})
+	if err != nil {
+		t.Fatalf("LinguistExcluded() error = %v", err)
+	}
+
+	want := map[string]string{"deps.lock": "linguist-generated", "vendor/lib.go": "linguist-vendored", "notes: v2.lock": "linguist-generated"}
+	if len(excluded) != len(want) {
+		t.Errorf("LinguistExcluded() = %v, want %v", excluded, want)
+	}
+	for path, attr := range want {
+		if excluded[path] != attr {
+			t.Errorf("excluded[%q] = %q, want %q", path, excluded[path], attr)
+		}
+	}
+}
+
+func TestIsAncestor(t *testing.T) {
+	dir := initRepo(t)
+	repo := NewRepo(dir)
+	first, _ := repo.Head()
+
+	writeFile(t, dir, "util.go", "package main\n")
+	gitRun(t, dir, "add", ".")
+	gitRun(t, dir, "commit", "-q", "-m", "Add util")
+	second, _ := repo.Head()
+
+	if !repo.IsAncestor(first, "HEAD") || !repo.IsAncestor(second, "HEAD") {
+		t.Error("earlier commits and HEAD itself should be ancestors of HEAD")
+	}
+	if repo.IsAncestor(second, first) {
+		t.Error("a later commit is not an ancestor of an earlier one")
+	}
+	if repo.IsAncestor("0000000000000000000000000000000000000000", "HEAD") {
+		t.Error("an unknown commit is not an ancestor")
+	}
+}
@@ -0,0 +1,330 @@
+// Package github posts council review results to GitHub pull requests: the
+// PR review with its inline comments, and the check run with its
+// annotations.
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/luuuc/council/internal/review"
+)
+
+// DefaultBaseURL is the GitHub REST API root. GitHub Enterprise Server
+// uses https://<host>/api/v3.
+const DefaultBaseURL = "https://api.github.com"
+
+// MaxAnnotations is how many annotations GitHub accepts per check run
+// request. Longer lists are sent in batches.
+const MaxAnnotations = 50
+
+// maxResponseSize caps how much of a response body is read.
+const maxResponseSize = 1 << 20
+
+// APIError is a non-2xx response from the GitHub API.
+type APIError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("github %s %s: %d %s", e.Method, e.Path, e.StatusCode, e.Message)
+}
+
+// IsUnprocessable reports whether err is a 422 from the API, which GitHub
+// returns when a review comment points outside the diff.
+func IsUnprocessable(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnprocessableEntity
+}
+
+// Client talks to the GitHub REST API.
+type Client struct {
+	BaseURL string // API root; DefaultBaseURL when empty
+	Token   string
+	HTTP    *http.Client
+}
+
+// NewClient creates a client for the API at baseURL ("" for github.com).
+func NewClient(baseURL, token string) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{BaseURL: strings.TrimRight(baseURL, "/"), Token: token, HTTP: &http.Client{}}
+}
+
+// Target is the pull request results are posted to.
+type Target struct {
+	Repo    string // "owner/name"
+	Number  int    // pull request number
+	HeadSHA string // commit the check run is attached to
+}
+
+// Result describes what Post did.
+type Result struct {
+	ReviewPosted   bool  // the PR review was created
+	FallbackPosted bool  // the review was rejected and posted as a plain comment instead
+	Repeated       int   // inline comments left out because an earlier review already made them
+	Superseded     int   // earlier council reviews and comments minimized as outdated
+	Outdated       int   // earlier inline comments minimized because their finding is gone
+	CheckRunID     int64 // check run created or updated; 0 when none
+	Annotations    int   // annotations sent to the check run
+}
+
+// Post publishes a council review to a pull request: the PR review, falling
+// back to a plain comment when GitHub rejects it with 422, then the check
+// run, created or updated in place when one with the same name already
+// exists for the head commit. The check run is skipped without a head SHA,
+// and posted even when the review could not be; the errors are returned
+// together.
+//
+// Earlier council reviews on the pull request, recognized by
+// review.GitHubReviewMarker, are superseded once the new one is up: they
+// are dismissed if they approved or requested changes and minimized as
+// outdated. Their inline comments are minimized when the new review no
+// longer reports the finding, and left in place, rather than posted again,
+// when it still does or when the finding was carried forward to it (see
+// review.GitHubOutput.Carried). Failing to supersede doesn't stop the new review
+// from being posted; the error is returned after the check run.
+func (c *Client) Post(ctx context.Context, t Target, out review.GitHubOutput) (Result, error) {
+	var res Result
+
+	prev, cleanupErr := c.findPrevious(ctx, t.Repo, t.Number)
+	if cleanupErr != nil {
+		prev = &previous{}
+	}
+
+	r := out.Review
+	var current map[string]bool
+	r.Comments, current = prev.withoutRepeats(out.Review.Comments)
+	res.Repeated = len(out.Review.Comments) - len(r.Comments)
+
+	reviewErr := c.CreateReview(ctx, t.Repo, t.Number, r)
+	switch {
+	case reviewErr == nil:
+		res.ReviewPosted = true
+	case IsUnprocessable(reviewErr):
+		if reviewErr = c.CreateComment(ctx, t.Repo, t.Number, FallbackBody(out.Review)); reviewErr == nil {
+			res.FallbackPosted = true
+		}
+	}
+
+	// Earlier reviews are only superseded by one that was posted.
+	if reviewErr == nil && cleanupErr == nil {
+		res.Superseded, res.Outdated, cleanupErr = c.supersede(ctx, t.Repo, t.Number, prev, current, out.Carried)
+	}
+
+	var checkErr error
+	if t.HeadSHA != "" {
+		res.CheckRunID, res.Annotations, checkErr = c.PostCheckRun(ctx, t.Repo, t.HeadSHA, out.CheckRun)
+	}
+	return res, errors.Join(reviewErr, checkErr, cleanupErr)
+}
+
+// CreateReview submits a PR review.
+func (c *Client) CreateReview(ctx context.Context, repo string, number int, r review.GitHubReview) error {
+	return c.do(ctx, http.MethodPost, fmt.Sprintf("/repos/%s/pulls/%d/reviews", repo, number), r, nil)
+}
+
+// CreateComment adds a plain comment to a pull request's conversation.
+func (c *Client) CreateComment(ctx context.Context, repo string, number int, body string) error {
+	payload := map[string]string{"body": body}
+	return c.do(ctx, http.MethodPost, fmt.Sprintf("/repos/%s/issues/%d/comments", repo, number), payload, nil)
+}
+
+// checkRunPayload is the request body for creating or updating a check run.
+type checkRunPayload struct {
+	Name       string                   `json:"name,omitempty"`
+	HeadSHA    string                   `json:"head_sha,omitempty"`
+	Status     string                   `json:"status,omitempty"`
+	Conclusion string                   `json:"conclusion,omitempty"`
+	Output     review.GitHubCheckOutput `json:"output"`
+}
+
+// PostCheckRun creates the check run for headSHA, or updates the one
+// already there with the same name, and sends its annotations in batches
+// of MaxAnnotations. GitHub appends annotations rather than replacing them,
+// so a run that already has some, from an earlier post, only gets its
+// status and summary updated. Returns the check run ID and the annotations
+// sent.
+func (c *Client) PostCheckRun(ctx context.Context, repo, headSHA string, run review.GitHubCheckRun) (int64, int, error) {
+	all := run.Output.Annotations
+	first, rest := all, []review.GitHubCheckAnnotation(nil)
+	if len(all) > MaxAnnotations {
+		first, rest = all[:MaxAnnotations], all[MaxAnnotations:]
+	}
+
+	output := run.Output
+	output.Annotations = first
+	payload := checkRunPayload{
+		Name:       run.Name,
+		HeadSHA:    headSHA,
+		Status:     run.Status,
+		Conclusion: run.Conclusion,
+		Output:     output,
+	}
+
+	id, annotated, err := c.findCheckRun(ctx, repo, headSHA, run.Name)
+	if err != nil {
+		return 0, 0, err
+	}
+	if annotated {
+		output.Annotations = nil
+		update := checkRunPayload{Status: run.Status, Conclusion: run.Conclusion, Output: output}
+		return id, 0, c.do(ctx, http.MethodPatch, fmt.Sprintf("/repos/%s/check-runs/%d", repo, id), update, nil)
+	}
+
+	var created struct {
+		ID int64 `json:"id"`
+	}
+	if id == 0 {
+		if err := c.do(ctx, http.MethodPost, fmt.Sprintf("/repos/%s/check-runs", repo), payload, &created); err != nil {
+			return 0, 0, err
+		}
+		id = created.ID
+	} else {
+		payload.HeadSHA = ""
+		if err := c.do(ctx, http.MethodPatch, fmt.Sprintf("/repos/%s/check-runs/%d", repo, id), payload, nil); err != nil {
+			return id, 0, err
+		}
+	}
+	sent := len(first)
+
+	// Each update appends its annotations to those already on the run
+	for len(rest) > 0 {
+		batch := rest
+		if len(batch) > MaxAnnotations {
+			batch = batch[:MaxAnnotations]
+		}
+		rest = rest[len(batch):]
+
+		output.Annotations = batch
+		update := checkRunPayload{Output: output}
+		if err := c.do(ctx, http.MethodPatch, fmt.Sprintf("/repos/%s/check-runs/%d", repo, id), update, nil); err != nil {
+			return id, sent, err
+		}
+		sent += len(batch)
+	}
+	return id, sent, nil
+}
+
+// findCheckRun returns the ID of the check run named name on headSHA, or 0
+// when there is none, and whether it has annotations.
+func (c *Client) findCheckRun(ctx context.Context, repo, headSHA, name string) (int64, bool, error) {
+	var list struct {
+		CheckRuns []struct {
+			ID     int64  `json:"id"`
+			Name   string `json:"name"`
+			Output struct {
+				AnnotationsCount int `json:"annotations_count"`
+			} `json:"output"`
+		} `json:"check_runs"`
+	}
+	path := fmt.Sprintf("/repos/%s/commits/%s/check-runs?check_name=%s", repo, headSHA, url.QueryEscape(name))
+	if err := c.do(ctx, http.MethodGet, path, nil, &list); err != nil {
+		return 0, false, err
+	}
+	for _, r := range list.CheckRuns {
+		if r.Name == name {
+			return r.ID, r.Output.AnnotationsCount > 0, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// FallbackBody renders a review as one markdown comment, with the inline
+// comments GitHub rejected listed after the body.
+func FallbackBody(r review.GitHubReview) string {
+	if len(r.Comments) == 0 {
+		return r.Body
+	}
+
+	var b strings.Builder
+	b.WriteString(r.Body)
+	b.WriteString("\n\n### Inline Comments\n")
+	for _, c := range r.Comments {
+		loc := c.Path
+		switch {
+		case c.StartLine > 0:
+			loc = fmt.Sprintf("%s:%d-%d", c.Path, c.StartLine, c.Line)
+		case c.Line > 0:
+			loc = fmt.Sprintf("%s:%d", c.Path, c.Line)
+		}
+		fmt.Fprintf(&b, "\n`%s`\n%s\n", loc, c.Body)
+	}
+	return b.String()
+}
+
+// do sends a JSON request to path under the base URL and decodes a JSON
+// response into out when out is non-nil. Non-2xx responses are returned as
+// *APIError.
+func (c *Client) do(ctx context.Context, method, path string, in, out any) error {
+	return c.send(ctx, method, c.BaseURL+path, in, out)
+}
+
+// send is do for an absolute URL.
+func (c *Client) send(ctx context.Context, method, url string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("encode github request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return fmt.Errorf("create github request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	httpClient := c.HTTP
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("github %s %s: %w", method, req.URL.Path, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return fmt.Errorf("read github response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var apiMsg struct {
+			Message string `json:"message"`
+		}
+		msg := strings.TrimSpace(string(data))
+		if json.Unmarshal(data, &apiMsg) == nil && apiMsg.Message != "" {
+			msg = apiMsg.Message
+		}
+		return &APIError{Method: method, Path: req.URL.Path, StatusCode: resp.StatusCode, Message: msg}
+	}
+
+	if out != nil && len(data) > 0 {
+		if err := json.Unmarshal(data, out); err != nil {
+			return fmt.Errorf("decode github response: %w", err)
+		}
+	}
+	return nil
+}
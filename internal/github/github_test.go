@@ -0,0 +1,259 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/luuuc/council/internal/review"
+)
+
+// fakeGitHub records the requests it receives and answers them from a
+// handler per "METHOD path" key.
+type fakeGitHub struct {
+	mu       sync.Mutex
+	requests []recorded
+	handlers map[string]func(w http.ResponseWriter, body []byte)
+}
+
+type recorded struct {
+	Method, Path string
+	Body         map[string]any
+	Auth         string
+}
+
+func newFakeGitHub(t *testing.T) (*fakeGitHub, *Client) {
+	t.Helper()
+	f := &fakeGitHub{handlers: make(map[string]func(http.ResponseWriter, []byte))}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		var body map[string]any
+		_ = json.Unmarshal(data, &body)
+
+		key := r.Method + " " + r.URL.Path
+		f.mu.Lock()
+		f.requests = append(f.requests, recorded{Method: r.Method, Path: r.URL.Path, Body: body, Auth: r.Header.Get("Authorization")})
+		h := f.handlers[key]
+		f.mu.Unlock()
+
+		switch {
+		case h != nil:
+			h(w, data)
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`[]`))
+		default:
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return f, NewClient(srv.URL, "secret")
+}
+
+func (f *fakeGitHub) paths() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []string
+	for _, r := range f.requests {
+		out = append(out, r.Method+" "+r.Path)
+	}
+	return out
+}
+
+func sampleOutput(annotations int) review.GitHubOutput {
+	out := review.GitHubOutput{
+		Review: review.GitHubReview{
+			Event: review.GitHubComment,
+			Body:  "## Council Review",
+			Comments: []review.GitHubReviewComment{
+				{Path: "main.go", StartLine: 2, Line: 3, Side: "RIGHT", StartSide: "RIGHT", Body: "Inline the helper"},
+			},
+		},
+		CheckRun: review.GitHubCheckRun{
+			Name:       "Council Review",
+			Status:     "completed",
+			Conclusion: "success",
+			Output:     review.GitHubCheckOutput{Title: "t", Summary: "s"},
+		},
+	}
+	for i := 0; i < annotations; i++ {
+		out.CheckRun.Output.Annotations = append(out.CheckRun.Output.Annotations, review.GitHubCheckAnnotation{
+			Path: "main.go", StartLine: i + 1, EndLine: i + 1, AnnotationLevel: "notice", Message: fmt.Sprint(i),
+		})
+	}
+	return out
+}
+
+func TestPostCreatesReviewAndCheckRun(t *testing.T) {
+	f, c := newFakeGitHub(t)
+	f.handlers["GET /repos/o/r/commits/abc/check-runs"] = func(w http.ResponseWriter, _ []byte) {
+		_, _ = w.Write([]byte(`{"check_runs": []}`))
+	}
+	f.handlers["POST /repos/o/r/check-runs"] = func(w http.ResponseWriter, _ []byte) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": 7}`))
+	}
+
+	res, err := c.Post(context.Background(), Target{Repo: "o/r", Number: 42, HeadSHA: "abc"}, sampleOutput(120))
+	if err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	if !res.ReviewPosted || res.FallbackPosted || res.CheckRunID != 7 || res.Annotations != 120 {
+		t.Errorf("unexpected result: %+v", res)
+	}
+
+	want := []string{
+		"GET /repos/o/r/pulls/42/reviews",
+		"GET /repos/o/r/issues/42/comments",
+		"POST /repos/o/r/pulls/42/reviews",
+		"GET /repos/o/r/commits/abc/check-runs",
+		"POST /repos/o/r/check-runs",
+		"PATCH /repos/o/r/check-runs/7",
+		"PATCH /repos/o/r/check-runs/7",
+	}
+	if got := f.paths(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("requests:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	batches := []int{50, 50, 20}
+	for i, r := range f.requests[4:] {
+		annotations := r.Body["output"].(map[string]any)["annotations"].([]any)
+		if len(annotations) != batches[i] {
+			t.Errorf("batch %d has %d annotations, want %d", i, len(annotations), batches[i])
+		}
+	}
+	if f.requests[4].Body["head_sha"] != "abc" {
+		t.Errorf("check run head_sha = %v", f.requests[4].Body["head_sha"])
+	}
+
+	comment := f.requests[2].Body["comments"].([]any)[0].(map[string]any)
+	if comment["start_line"] != float64(2) || comment["line"] != float64(3) {
+		t.Errorf("review comment = %v", comment)
+	}
+	if f.requests[0].Auth != "Bearer secret" {
+		t.Errorf("Authorization = %q", f.requests[0].Auth)
+	}
+}
+
+func TestPostUpdatesExistingCheckRun(t *testing.T) {
+	f, c := newFakeGitHub(t)
+	f.handlers["GET /repos/o/r/commits/abc/check-runs"] = func(w http.ResponseWriter, _ []byte) {
+		_, _ = w.Write([]byte(`{"check_runs": [{"id": 3, "name": "Council Review"}]}`))
+	}
+
+	res, err := c.Post(context.Background(), Target{Repo: "o/r", Number: 1, HeadSHA: "abc"}, sampleOutput(1))
+	if err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	if res.CheckRunID != 3 {
+		t.Errorf("CheckRunID = %d, want 3", res.CheckRunID)
+	}
+	paths := f.paths()
+	if paths[len(paths)-1] != "PATCH /repos/o/r/check-runs/3" {
+		t.Errorf("expected the existing check run to be updated, got %v", paths)
+	}
+	if _, ok := f.requests[len(f.requests)-1].Body["head_sha"]; ok {
+		t.Error("update should not send head_sha")
+	}
+}
+
+func TestPostDoesNotRepeatAnnotationsOnRerun(t *testing.T) {
+	f, c := newFakeGitHub(t)
+	f.handlers["GET /repos/o/r/commits/abc/check-runs"] = func(w http.ResponseWriter, _ []byte) {
+		_, _ = w.Write([]byte(`{"check_runs": [{"id": 3, "name": "Council Review", "output": {"annotations_count": 60}}]}`))
+	}
+
+	res, err := c.Post(context.Background(), Target{Repo: "o/r", Number: 1, HeadSHA: "abc"}, sampleOutput(60))
+	if err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	if res.CheckRunID != 3 || res.Annotations != 0 {
+		t.Errorf("unexpected result: %+v", res)
+	}
+
+	var patches int
+	for _, r := range f.requests {
+		if r.Method != http.MethodPatch {
+			continue
+		}
+		patches++
+		output := r.Body["output"].(map[string]any)
+		if _, ok := output["annotations"]; ok {
+			t.Error("an annotated check run should not be sent its annotations again")
+		}
+		if r.Body["conclusion"] != "success" || output["summary"] == "" {
+			t.Errorf("update should carry the conclusion and summary, got %v", r.Body)
+		}
+	}
+	if patches != 1 {
+		t.Errorf("expected one update of the check run, got %d", patches)
+	}
+}
+
+func TestPostFallsBackToCommentOn422(t *testing.T) {
+	f, c := newFakeGitHub(t)
+	f.handlers["POST /repos/o/r/pulls/5/reviews"] = func(w http.ResponseWriter, _ []byte) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"message": "Line could not be resolved"}`))
+	}
+
+	res, err := c.Post(context.Background(), Target{Repo: "o/r", Number: 5}, sampleOutput(0))
+	if err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	if res.ReviewPosted || !res.FallbackPosted || res.CheckRunID != 0 {
+		t.Errorf("unexpected result: %+v", res)
+	}
+
+	last := f.requests[len(f.requests)-1]
+	if last.Path != "/repos/o/r/issues/5/comments" {
+		t.Fatalf("fallback went to %s", last.Path)
+	}
+	body := last.Body["body"].(string)
+	for _, want := range []string{"## Council Review", "`main.go:2-3`", "Inline the helper"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("fallback comment missing %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestPostReturnsOtherErrors(t *testing.T) {
+	f, c := newFakeGitHub(t)
+	f.handlers["POST /repos/o/r/pulls/5/reviews"] = func(w http.ResponseWriter, _ []byte) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message": "Resource not accessible by integration"}`))
+	}
+	f.handlers["GET /repos/o/r/commits/abc123/check-runs"] = func(w http.ResponseWriter, _ []byte) {
+		_, _ = w.Write([]byte(`{"check_runs": []}`))
+	}
+
+	res, err := c.Post(context.Background(), Target{Repo: "o/r", Number: 5, HeadSHA: "abc123"}, sampleOutput(0))
+	if err == nil || !strings.Contains(err.Error(), "403 Resource not accessible") {
+		t.Fatalf("expected a 403 error, got %v", err)
+	}
+	if IsUnprocessable(err) {
+		t.Error("403 is not a 422")
+	}
+	if res.ReviewPosted || res.FallbackPosted {
+		t.Errorf("unexpected result: %+v", res)
+	}
+	if got := f.paths(); got[len(got)-1] != "POST /repos/o/r/check-runs" || slices.Contains(got, "POST /repos/o/r/issues/5/comments") {
+		t.Errorf("the check run should still be posted, and no fallback comment: %v", got)
+	}
+}
+
+func TestNewClientDefaultBaseURL(t *testing.T) {
+	if c := NewClient("", ""); c.BaseURL != DefaultBaseURL {
+		t.Errorf("BaseURL = %q, want %q", c.BaseURL, DefaultBaseURL)
+	}
+	if c := NewClient("https://ghe.example.com/api/v3/", ""); c.BaseURL != "https://ghe.example.com/api/v3" {
+		t.Errorf("BaseURL = %q, want trailing slash trimmed", c.BaseURL)
+	}
+}
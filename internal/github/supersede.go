@@ -0,0 +1,321 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/luuuc/council/internal/review"
+)
+
+// perPage is the page size used when listing reviews and comments.
+const perPage = 100
+
+// supersededMessage explains why an earlier council review was dismissed.
+const supersededMessage = "Superseded by a newer council review."
+
+// previousReview is a review council posted on an earlier run.
+type previousReview struct {
+	ID     int64  `json:"id"`
+	NodeID string `json:"node_id"`
+	State  string `json:"state"`
+	Body   string `json:"body"`
+}
+
+// previousComment is an inline or conversation comment.
+type previousComment struct {
+	ID       int64  `json:"id"`
+	NodeID   string `json:"node_id"`
+	ReviewID int64  `json:"pull_request_review_id"`
+	Path     string `json:"path"`
+	Line     *int   `json:"line"` // nil once the diff no longer shows the line
+	Body     string `json:"body"`
+}
+
+// previous is what earlier council runs left on a pull request.
+type previous struct {
+	reviews  []previousReview
+	inline   []previousComment // inline comments of those reviews
+	comments []previousComment // fallback conversation comments
+}
+
+// findPrevious lists the reviews, inline comments and fallback comments
+// earlier council runs posted, recognized by review.GitHubReviewMarker.
+func (c *Client) findPrevious(ctx context.Context, repo string, number int) (*previous, error) {
+	p := &previous{}
+
+	var reviews []previousReview
+	if err := list(ctx, c, fmt.Sprintf("/repos/%s/pulls/%d/reviews", repo, number), &reviews); err != nil {
+		return nil, err
+	}
+	ids := make(map[int64]bool)
+	for _, r := range reviews {
+		if strings.Contains(r.Body, review.GitHubReviewMarker) {
+			p.reviews = append(p.reviews, r)
+			ids[r.ID] = true
+		}
+	}
+
+	if len(ids) > 0 {
+		var inline []previousComment
+		if err := list(ctx, c, fmt.Sprintf("/repos/%s/pulls/%d/comments", repo, number), &inline); err != nil {
+			return nil, err
+		}
+		for _, ic := range inline {
+			if ids[ic.ReviewID] {
+				p.inline = append(p.inline, ic)
+			}
+		}
+	}
+
+	var comments []previousComment
+	if err := list(ctx, c, fmt.Sprintf("/repos/%s/issues/%d/comments", repo, number), &comments); err != nil {
+		return nil, err
+	}
+	for _, ic := range comments {
+		if strings.Contains(ic.Body, review.GitHubReviewMarker) {
+			p.comments = append(p.comments, ic)
+		}
+	}
+	return p, nil
+}
+
+// EarlierReview is an earlier council review of a pull request that
+// recorded the commit it covered.
+type EarlierReview struct {
+	Commit string
+	// Result holds the anchored findings the review embedded (see
+	// review.GitHubReviewedFindings), or is nil when it embedded none.
+	Result *review.SynthesizedResult
+}
+
+// EarlierReviews returns the earlier council reviews of a pull request that
+// recorded a reviewed commit (see review.GitHubReviewedCommit), newest
+// first. Fallback comments count as reviews.
+func (c *Client) EarlierReviews(ctx context.Context, repo string, number int) ([]EarlierReview, error) {
+	type posted struct {
+		at   time.Time
+		body string
+	}
+	var found []posted
+
+	var reviews []struct {
+		Body        string    `json:"body"`
+		SubmittedAt time.Time `json:"submitted_at"`
+	}
+	if err := list(ctx, c, fmt.Sprintf("/repos/%s/pulls/%d/reviews", repo, number), &reviews); err != nil {
+		return nil, err
+	}
+	for _, r := range reviews {
+		found = append(found, posted{r.SubmittedAt, r.Body})
+	}
+
+	var comments []struct {
+		Body      string    `json:"body"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+	if err := list(ctx, c, fmt.Sprintf("/repos/%s/issues/%d/comments", repo, number), &comments); err != nil {
+		return nil, err
+	}
+	for _, ic := range comments {
+		found = append(found, posted{ic.CreatedAt, ic.Body})
+	}
+
+	slices.SortStableFunc(found, func(a, b posted) int { return b.at.Compare(a.at) })
+	var earlier []EarlierReview
+	for _, f := range found {
+		sha := review.GitHubReviewedCommit(f.body)
+		if sha == "" || !strings.Contains(f.body, review.GitHubReviewMarker) {
+			continue
+		}
+		result, _ := review.GitHubReviewedFindings(f.body)
+		earlier = append(earlier, EarlierReview{Commit: sha, Result: result})
+	}
+	return earlier, nil
+}
+
+// list fetches every page of a GitHub list endpoint into out, which must
+// point to a slice.
+func list[T any](ctx context.Context, c *Client, path string, out *[]T) error {
+	for page := 1; ; page++ {
+		var batch []T
+		if err := c.do(ctx, http.MethodGet, fmt.Sprintf("%s?per_page=%d&page=%d", path, perPage, page), nil, &batch); err != nil {
+			return err
+		}
+		*out = append(*out, batch...)
+		if len(batch) < perPage {
+			return nil
+		}
+	}
+}
+
+// commentKey identifies an inline comment across runs. Lines are left out
+// because they shift as the branch changes.
+func commentKey(path, body string) string {
+	return path + "\x00" + body
+}
+
+// withoutRepeats drops the new inline comments an earlier council review
+// already made on a line the diff still shows, so unchanged findings are
+// not posted twice. Returns the remaining comments and the keys of every
+// new comment.
+func (p *previous) withoutRepeats(comments []review.GitHubReviewComment) ([]review.GitHubReviewComment, map[string]bool) {
+	posted := make(map[string]bool)
+	for _, ic := range p.inline {
+		if ic.Line != nil {
+			posted[commentKey(ic.Path, ic.Body)] = true
+		}
+	}
+
+	keys := make(map[string]bool, len(comments))
+	var kept []review.GitHubReviewComment
+	for _, c := range comments {
+		key := commentKey(c.Path, c.Body)
+		keys[key] = true
+		if !posted[key] {
+			kept = append(kept, c)
+		}
+	}
+	return kept, keys
+}
+
+// supersede retires what earlier council runs posted once a new review is
+// up: approvals and change requests are dismissed so they stop counting,
+// old reviews and fallback comments are minimized as outdated, and so are
+// inline comments whose finding the new review no longer reports, unless
+// they are on a file in carried, whose findings still stand. What is
+// already minimized is left alone. Returns how many reviews and comments,
+// and how many inline comments, were newly retired.
+func (c *Client) supersede(ctx context.Context, repo string, number int, p *previous, current map[string]bool, carried []string) (int, int, error) {
+	var errs []error
+	superseded, outdated := 0, 0
+
+	// What an earlier run already hid stays hidden and isn't counted again
+	var ids []string
+	for _, r := range p.reviews {
+		ids = append(ids, r.NodeID)
+	}
+	for _, ic := range slices.Concat(p.comments, p.inline) {
+		ids = append(ids, ic.NodeID)
+	}
+	hidden, err := c.minimized(ctx, ids)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	for _, r := range p.reviews {
+		if r.State == "APPROVED" || r.State == "CHANGES_REQUESTED" {
+			path := fmt.Sprintf("/repos/%s/pulls/%d/reviews/%d/dismissals", repo, number, r.ID)
+			if err := c.do(ctx, http.MethodPut, path, map[string]string{"message": supersededMessage}, nil); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if hidden[r.NodeID] {
+			continue
+		}
+		if err := c.Minimize(ctx, r.NodeID); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		superseded++
+	}
+
+	for _, ic := range p.comments {
+		if hidden[ic.NodeID] {
+			continue
+		}
+		if err := c.Minimize(ctx, ic.NodeID); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		superseded++
+	}
+
+	for _, ic := range p.inline {
+		if hidden[ic.NodeID] || current[commentKey(ic.Path, ic.Body)] || slices.Contains(carried, ic.Path) {
+			continue
+		}
+		if err := c.Minimize(ctx, ic.NodeID); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		outdated++
+	}
+
+	return superseded, outdated, joinErrors("supersede previous council reviews", errs)
+}
+
+// Minimize collapses a review or comment as outdated, the same as "Hide →
+// Outdated" in the GitHub UI.
+func (c *Client) Minimize(ctx context.Context, nodeID string) error {
+	query := `mutation($id: ID!) { minimizeComment(input: {subjectId: $id, classifier: OUTDATED}) { clientMutationId } }`
+	return c.graphQL(ctx, query, map[string]any{"id": nodeID}, nil)
+}
+
+// minimized returns which of the reviews and comments ids names are
+// already minimized, querying them perPage at a time.
+func (c *Client) minimized(ctx context.Context, ids []string) (map[string]bool, error) {
+	query := `query($ids: [ID!]!) { nodes(ids: $ids) { ... on Minimizable { isMinimized } ... on Node { id } } }`
+	hidden := make(map[string]bool)
+	for batch := range slices.Chunk(ids, perPage) {
+		var data struct {
+			Nodes []*struct {
+				ID          string `json:"id"`
+				IsMinimized bool   `json:"isMinimized"`
+			} `json:"nodes"`
+		}
+		if err := c.graphQL(ctx, query, map[string]any{"ids": batch}, &data); err != nil {
+			return hidden, err
+		}
+		for _, n := range data.Nodes {
+			if n != nil && n.IsMinimized {
+				hidden[n.ID] = true
+			}
+		}
+	}
+	return hidden, nil
+}
+
+// graphQL runs a GraphQL query, decoding its data into out unless out is
+// nil and turning errors in the response into a Go error.
+func (c *Client) graphQL(ctx context.Context, query string, vars map[string]any, out any) error {
+	var resp struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	payload := map[string]any{"query": query, "variables": vars}
+	if err := c.send(ctx, http.MethodPost, c.graphQLURL(), payload, &resp); err != nil {
+		return err
+	}
+	if len(resp.Errors) > 0 {
+		return fmt.Errorf("github graphql: %s", resp.Errors[0].Message)
+	}
+	if out != nil && len(resp.Data) > 0 && string(resp.Data) != "null" {
+		return json.Unmarshal(resp.Data, out)
+	}
+	return nil
+}
+
+// graphQLURL derives the GraphQL endpoint from the REST base URL:
+// https://api.github.com/graphql, or https://<host>/api/graphql on GitHub
+// Enterprise Server.
+func (c *Client) graphQLURL() string {
+	if base, ok := strings.CutSuffix(c.BaseURL, "/api/v3"); ok {
+		return base + "/api/graphql"
+	}
+	return c.BaseURL + "/graphql"
+}
+
+// joinErrors summarizes errors from a best-effort step, or returns nil.
+func joinErrors(step string, errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s: %d failed, first: %w", step, len(errs), errs[0])
+}
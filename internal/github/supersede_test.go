@@ -0,0 +1,272 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/luuuc/council/internal/review"
+)
+
+func TestPostSupersedesPreviousReviews(t *testing.T) {
+	f, c := newFakeGitHub(t)
+	marker := review.GitHubReviewMarker
+
+	f.handlers["GET /repos/o/r/pulls/9/reviews"] = func(w http.ResponseWriter, _ []byte) {
+		fmt.Fprintf(w, `[
+			{"id": 1, "node_id": "R1", "state": "CHANGES_REQUESTED", "body": %q},
+			{"id": 2, "node_id": "R2", "state": "COMMENTED", "body": "human review"}
+		]`, marker+"\n## Council Review")
+	}
+	f.handlers["GET /repos/o/r/pulls/9/comments"] = func(w http.ResponseWriter, _ []byte) {
+		_, _ = w.Write([]byte(`[
+			{"id": 10, "node_id": "C10", "pull_request_review_id": 1, "path": "main.go", "line": 3, "body": "Inline the helper"},
+			{"id": 11, "node_id": "C11", "pull_request_review_id": 1, "path": "main.go", "line": 8, "body": "Fixed since"},
+			{"id": 12, "node_id": "C12", "pull_request_review_id": 2, "path": "main.go", "line": 8, "body": "Human comment"}
+		]`))
+	}
+	f.handlers["GET /repos/o/r/issues/9/comments"] = func(w http.ResponseWriter, _ []byte) {
+		fmt.Fprintf(w, `[{"id": 20, "node_id": "I20", "body": %q}, {"id": 21, "node_id": "I21", "body": "thanks"}]`, marker+"\nfallback")
+	}
+
+	res, err := c.Post(context.Background(), Target{Repo: "o/r", Number: 9}, sampleOutput(0))
+	if err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	if res.Repeated != 1 || res.Superseded != 2 || res.Outdated != 1 {
+		t.Errorf("unexpected result: %+v", res)
+	}
+
+	var minimized []string
+	var dismissed, posted []string
+	for _, r := range f.requests {
+		switch {
+		case r.Path == "/graphql" && strings.Contains(r.Body["query"].(string), "minimizeComment"):
+			vars := r.Body["variables"].(map[string]any)
+			minimized = append(minimized, vars["id"].(string))
+			if !strings.Contains(r.Body["query"].(string), "classifier: OUTDATED") {
+				t.Errorf("minimize should classify as outdated: %s", r.Body["query"])
+			}
+		case strings.HasSuffix(r.Path, "/dismissals"):
+			dismissed = append(dismissed, r.Path)
+		case r.Method == http.MethodPost && r.Path == "/repos/o/r/pulls/9/reviews":
+			comments, _ := r.Body["comments"].([]any)
+			for range comments {
+				posted = append(posted, "comment")
+			}
+		}
+	}
+
+	sort.Strings(minimized)
+	if strings.Join(minimized, ",") != "C11,I20,R1" {
+		t.Errorf("minimized %v, want the old review, its fallback comment and the resolved inline comment", minimized)
+	}
+	if len(dismissed) != 1 || dismissed[0] != "/repos/o/r/pulls/9/reviews/1/dismissals" {
+		t.Errorf("dismissed %v, want the old change request", dismissed)
+	}
+	if len(posted) != 0 {
+		t.Errorf("a finding already commented on should not be posted again, got %d comments", len(posted))
+	}
+}
+
+func TestPostSkipsAlreadySuperseded(t *testing.T) {
+	f, c := newFakeGitHub(t)
+	marker := review.GitHubReviewMarker
+
+	f.handlers["GET /repos/o/r/pulls/9/reviews"] = func(w http.ResponseWriter, _ []byte) {
+		fmt.Fprintf(w, `[
+			{"id": 1, "node_id": "R1", "state": "DISMISSED", "body": %q},
+			{"id": 2, "node_id": "R2", "state": "CHANGES_REQUESTED", "body": %q}
+		]`, marker, marker)
+	}
+	f.handlers["GET /repos/o/r/pulls/9/comments"] = func(w http.ResponseWriter, _ []byte) {
+		_, _ = w.Write([]byte(`[
+			{"id": 10, "node_id": "C10", "pull_request_review_id": 1, "path": "main.go", "line": 3, "body": "Fixed long ago"},
+			{"id": 11, "node_id": "C11", "pull_request_review_id": 2, "path": "main.go", "line": 8, "body": "Fixed since"}
+		]`))
+	}
+	f.handlers["POST /graphql"] = func(w http.ResponseWriter, body []byte) {
+		if strings.Contains(string(body), "isMinimized") {
+			_, _ = w.Write([]byte(`{"data": {"nodes": [
+				{"id": "R1", "isMinimized": true},
+				{"id": "R2", "isMinimized": false},
+				{"id": "C10", "isMinimized": true},
+				{"id": "C11", "isMinimized": false}
+			]}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": {}}`))
+	}
+
+	res, err := c.Post(context.Background(), Target{Repo: "o/r", Number: 9}, sampleOutput(0))
+	if err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	if res.Superseded != 1 || res.Outdated != 1 {
+		t.Errorf("unexpected result: %+v, want only the newly hidden review and comment counted", res)
+	}
+
+	var minimized, dismissed []string
+	for _, r := range f.requests {
+		switch {
+		case r.Path == "/graphql" && strings.Contains(r.Body["query"].(string), "minimizeComment"):
+			minimized = append(minimized, r.Body["variables"].(map[string]any)["id"].(string))
+		case strings.HasSuffix(r.Path, "/dismissals"):
+			dismissed = append(dismissed, r.Path)
+		}
+	}
+	sort.Strings(minimized)
+	if strings.Join(minimized, ",") != "C11,R2" {
+		t.Errorf("minimized %v, want only what isn't hidden yet", minimized)
+	}
+	if len(dismissed) != 1 || dismissed[0] != "/repos/o/r/pulls/9/reviews/2/dismissals" {
+		t.Errorf("dismissed %v, want only the change request still standing", dismissed)
+	}
+}
+
+func TestPostKeepsPreviousWhenReviewFails(t *testing.T) {
+	f, c := newFakeGitHub(t)
+	f.handlers["GET /repos/o/r/pulls/9/reviews"] = func(w http.ResponseWriter, _ []byte) {
+		fmt.Fprintf(w, `[{"id": 1, "node_id": "R1", "state": "COMMENTED", "body": %q}]`, review.GitHubReviewMarker)
+	}
+	f.handlers["POST /repos/o/r/pulls/9/reviews"] = func(w http.ResponseWriter, _ []byte) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	if _, err := c.Post(context.Background(), Target{Repo: "o/r", Number: 9}, sampleOutput(0)); err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, p := range f.paths() {
+		if strings.Contains(p, "/graphql") {
+			t.Errorf("previous review should stay until a new one is posted, got %s", p)
+		}
+	}
+}
+
+func TestPostReportsSupersedeErrors(t *testing.T) {
+	f, c := newFakeGitHub(t)
+	f.handlers["GET /repos/o/r/pulls/9/reviews"] = func(w http.ResponseWriter, _ []byte) {
+		fmt.Fprintf(w, `[{"id": 1, "node_id": "R1", "state": "COMMENTED", "body": %q}]`, review.GitHubReviewMarker)
+	}
+	f.handlers["POST /graphql"] = func(w http.ResponseWriter, _ []byte) {
+		_, _ = w.Write([]byte(`{"errors": [{"message": "Resource not accessible by integration"}]}`))
+	}
+
+	res, err := c.Post(context.Background(), Target{Repo: "o/r", Number: 9}, sampleOutput(0))
+	if !res.ReviewPosted {
+		t.Error("the new review should be posted even when superseding fails")
+	}
+	if err == nil || !strings.Contains(err.Error(), "Resource not accessible") {
+		t.Errorf("expected the graphql error, got %v", err)
+	}
+}
+
+func TestEarlierReviews(t *testing.T) {
+	f, c := newFakeGitHub(t)
+	marker := review.GitHubReviewMarker
+	withFindings := review.FormatGitHubReview(&review.SynthesizedResult{
+		Verdict:  review.VerdictBlock,
+		Reviewed: "aaa",
+		Perspectives: []review.ExpertVerdict{{
+			Expert:   "Kent Beck",
+			Verdict:  review.VerdictBlock,
+			Findings: []review.Finding{{File: "a.go", StartLine: 3, EndLine: 3, Message: "untested"}},
+		}},
+	}, "", 1, nil).Review.Body
+	f.handlers["GET /repos/o/r/pulls/9/reviews"] = func(w http.ResponseWriter, _ []byte) {
+		fmt.Fprintf(w, `[
+			{"body": %q, "submitted_at": "2026-01-01T10:00:00Z"},
+			{"body": %q, "submitted_at": "2026-01-03T10:00:00Z"},
+			{"body": "<!-- council-reviewed: ccc -->", "submitted_at": "2026-01-04T10:00:00Z"}
+		]`, withFindings, marker+"\n## Council Review")
+	}
+	f.handlers["GET /repos/o/r/issues/9/comments"] = func(w http.ResponseWriter, _ []byte) {
+		fmt.Fprintf(w, `[{"body": %q, "created_at": "2026-01-02T10:00:00Z"}]`, marker+"\n<!-- council-reviewed: bbb -->")
+	}
+
+	earlier, err := c.EarlierReviews(context.Background(), "o/r", 9)
+	if err != nil {
+		t.Fatalf("EarlierReviews() error = %v", err)
+	}
+	if len(earlier) != 2 || earlier[0].Commit != "bbb" || earlier[1].Commit != "aaa" {
+		t.Fatalf("EarlierReviews() = %+v, want the council reviews recording a commit, newest first", earlier)
+	}
+	if earlier[0].Result != nil {
+		t.Errorf("a review without embedded findings should have no result, got %+v", earlier[0].Result)
+	}
+	if r := earlier[1].Result; r == nil || len(r.Perspectives) != 1 || r.Perspectives[0].Findings[0].Message != "untested" {
+		t.Errorf("expected the embedded findings of the earlier review, got %+v", r)
+	}
+}
+
+func TestPostKeepsInlineCommentsOnCarriedFiles(t *testing.T) {
+	f, c := newFakeGitHub(t)
+	f.handlers["GET /repos/o/r/pulls/9/reviews"] = func(w http.ResponseWriter, _ []byte) {
+		fmt.Fprintf(w, `[{"id": 1, "node_id": "R1", "state": "COMMENTED", "body": %q}]`, review.GitHubReviewMarker)
+	}
+	f.handlers["GET /repos/o/r/pulls/9/comments"] = func(w http.ResponseWriter, _ []byte) {
+		_, _ = w.Write([]byte(`[
+			{"id": 11, "node_id": "C11", "pull_request_review_id": 1, "path": "kept.go", "line": 4, "body": "old"},
+			{"id": 12, "node_id": "C12", "pull_request_review_id": 1, "path": "changed.go", "line": 8, "body": "old"}
+		]`))
+	}
+
+	out := sampleOutput(0)
+	out.Carried = []string{"kept.go"}
+	res, err := c.Post(context.Background(), Target{Repo: "o/r", Number: 9}, out)
+	if err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+
+	var minimized []string
+	for _, r := range f.requests {
+		if r.Path == "/graphql" && strings.Contains(r.Body["query"].(string), "minimizeComment") {
+			minimized = append(minimized, r.Body["variables"].(map[string]any)["id"].(string))
+		}
+	}
+	sort.Strings(minimized)
+	if strings.Join(minimized, ",") != "C12,R1" || res.Outdated != 1 {
+		t.Errorf("minimized %v, want the old review and only the inline comment on the changed file", minimized)
+	}
+}
+
+func TestListPaginates(t *testing.T) {
+	f, c := newFakeGitHub(t)
+	calls := 0
+	f.handlers["GET /items"] = func(w http.ResponseWriter, _ []byte) {
+		calls++
+		n := perPage
+		if calls == 2 {
+			n = 3
+		}
+		items := make([]string, n)
+		for i := range items {
+			items[i] = "{}"
+		}
+		_, _ = w.Write([]byte("[" + strings.Join(items, ",") + "]"))
+	}
+
+	var got []struct{}
+	if err := list(context.Background(), c, "/items", &got); err != nil {
+		t.Fatalf("list() error = %v", err)
+	}
+	if len(got) != perPage+3 || calls != 2 {
+		t.Errorf("got %d items in %d calls, want %d in 2", len(got), calls, perPage+3)
+	}
+}
+
+func TestGraphQLURL(t *testing.T) {
+	tests := map[string]string{
+		"https://api.github.com":         "https://api.github.com/graphql",
+		"https://ghe.example.com/api/v3": "https://ghe.example.com/api/graphql",
+		"http://127.0.0.1:8080":          "http://127.0.0.1:8080/graphql",
+	}
+	for base, want := range tests {
+		if got := NewClient(base, "").graphQLURL(); got != want {
+			t.Errorf("graphQLURL(%s) = %s, want %s", base, got, want)
+		}
+	}
+}
@@ -0,0 +1,285 @@
+// Package history records council review results in .council/history/ so
+// past verdicts can be listed, inspected and compared across revisions.
+package history
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/luuuc/council/internal/config"
+	"github.com/luuuc/council/internal/git"
+	"github.com/luuuc/council/internal/review"
+)
+
+// HistoryFile is the JSONL file inside config.HistoryDir holding one entry per line.
+const HistoryFile = "reviews.jsonl"
+
+// Entry is one recorded review.
+type Entry struct {
+	ID       string                    `json:"id"`
+	Time     time.Time                 `json:"time"`
+	Head     string                    `json:"head,omitempty"`     // git HEAD when the review ran
+	Branch   string                    `json:"branch,omitempty"`   // git branch when the review ran
+	Reviewed string                    `json:"reviewed,omitempty"` // commit the review covered everything up to; set for --base
+	Pack     string                    `json:"pack,omitempty"`
+	Backend  string                    `json:"backend"` // see review.BackendName
+	Model    string                    `json:"model,omitempty"`
+	Result   *review.SynthesizedResult `json:"result"`
+}
+
+// ShortHead returns the first 8 characters of the HEAD SHA.
+func (e *Entry) ShortHead() string {
+	if len(e.Head) > 8 {
+		return e.Head[:8]
+	}
+	return e.Head
+}
+
+// NewEntry builds an entry for result, stamped with the current time and,
+// when run inside a git repository, HEAD and branch.
+func NewEntry(result *review.SynthesizedResult, packName, backend, model string) *Entry {
+	e := &Entry{
+		Time:    time.Now().UTC(),
+		Pack:    packName,
+		Backend: backend,
+		Model:   model,
+		Result:  result,
+	}
+
+	repo := git.NewRepo("")
+	if head, err := repo.Head(); err == nil {
+		e.Head = head
+	}
+	if branch, err := repo.Branch(); err == nil {
+		e.Branch = branch
+	}
+
+	h := sha256.Sum256([]byte(e.Time.Format(time.RFC3339Nano) + e.Head + backend))
+	e.ID = hex.EncodeToString(h[:])[:8]
+	return e
+}
+
+// path returns the history file path.
+func path() string {
+	return config.Path(config.HistoryDir, HistoryFile)
+}
+
+// Record appends an entry to the history file.
+func Record(e *Entry) error {
+	dir := config.Path(config.HistoryDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	// History is a local record; keep it out of version control.
+	ignore := filepath.Join(dir, ".gitignore")
+	if _, err := os.Stat(ignore); os.IsNotExist(err) {
+		_ = os.WriteFile(ignore, []byte("*\n"), 0644)
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to marshal history entry: %w", err)
+	}
+
+	f, err := os.OpenFile(path(), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open history: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	return nil
+}
+
+// List returns all recorded entries, oldest first. Lines that can't be
+// parsed are skipped.
+func List() ([]*Entry, error) {
+	f, err := os.Open(path())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var entries []*Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16<<20)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(line, &e); err != nil || e.Result == nil {
+			continue
+		}
+		entries = append(entries, &e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+	return entries, nil
+}
+
+// Get finds an entry by ID or unique ID prefix. "latest" returns the most
+// recent entry.
+func Get(id string) (*Entry, error) {
+	entries, err := List()
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no reviews recorded yet")
+	}
+
+	if id == "latest" {
+		return entries[len(entries)-1], nil
+	}
+
+	var matches []*Entry
+	for _, e := range entries {
+		if e.ID == id {
+			return e, nil
+		}
+		if strings.HasPrefix(e.ID, id) {
+			matches = append(matches, e)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("review '%s' not found", id)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("review id '%s' is ambiguous (%d matches)", id, len(matches))
+	}
+}
+
+// LastReviewed returns the most recent entry for branch and pack with a
+// Reviewed commit that reachable accepts, or nil when there is none.
+// Incremental review passes "is an ancestor of HEAD", so a review of
+// commits a force-push has since rewritten is never continued from.
+func LastReviewed(branch, pack string, reachable func(sha string) bool) (*Entry, error) {
+	entries, err := List()
+	if err != nil {
+		return nil, err
+	}
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		if e.Reviewed == "" || e.Branch != branch || e.Pack != pack {
+			continue
+		}
+		if reachable(e.Reviewed) {
+			return e, nil
+		}
+	}
+	return nil, nil
+}
+
+// ExpertChange describes how one expert's verdict moved between two reviews.
+// From or To is empty when the expert took part in only one of them. Notes
+// are findings rendered one per line (see review.Finding.String).
+type ExpertChange struct {
+	Expert       string         `json:"expert"`
+	From         review.Verdict `json:"from,omitempty"`
+	To           review.Verdict `json:"to,omitempty"`
+	AddedNotes   []string       `json:"added_notes,omitempty"`
+	RemovedNotes []string       `json:"removed_notes,omitempty"`
+}
+
+// Changed reports whether the verdict or the notes differ.
+func (c ExpertChange) Changed() bool {
+	return c.From != c.To || len(c.AddedNotes) > 0 || len(c.RemovedNotes) > 0
+}
+
+// Comparison is the difference between two recorded reviews.
+type Comparison struct {
+	From    *Entry         `json:"from"`
+	To      *Entry         `json:"to"`
+	Experts []ExpertChange `json:"experts"`
+}
+
+// Compare diffs two entries expert by expert, in the order experts appear
+// in from followed by any experts new in to.
+func Compare(from, to *Entry) *Comparison {
+	type side struct {
+		verdict review.Verdict
+		notes   []string
+	}
+	collect := func(e *Entry) (map[string]*side, []string) {
+		byExpert := make(map[string]*side)
+		var order []string
+		for _, p := range e.Result.Perspectives {
+			s, ok := byExpert[p.Expert]
+			if !ok {
+				s = &side{}
+				byExpert[p.Expert] = s
+				order = append(order, p.Expert)
+			}
+			// Per-file reviews repeat experts; keep the most severe verdict.
+			if s.verdict == "" || p.Verdict.Severity() > s.verdict.Severity() {
+				s.verdict = p.Verdict
+			}
+			for _, f := range p.AllFindings() {
+				s.notes = append(s.notes, f.String())
+			}
+		}
+		return byExpert, order
+	}
+
+	fromSides, fromOrder := collect(from)
+	toSides, toOrder := collect(to)
+
+	order := fromOrder
+	for _, id := range toOrder {
+		if _, ok := fromSides[id]; !ok {
+			order = append(order, id)
+		}
+	}
+
+	cmp := &Comparison{From: from, To: to}
+	for _, id := range order {
+		change := ExpertChange{Expert: id}
+		var before, after []string
+		if s, ok := fromSides[id]; ok {
+			change.From = s.verdict
+			before = s.notes
+		}
+		if s, ok := toSides[id]; ok {
+			change.To = s.verdict
+			after = s.notes
+		}
+		change.AddedNotes = difference(after, before)
+		change.RemovedNotes = difference(before, after)
+		cmp.Experts = append(cmp.Experts, change)
+	}
+	return cmp
+}
+
+// difference returns the notes in a that are not in b.
+func difference(a, b []string) []string {
+	seen := make(map[string]bool, len(b))
+	for _, n := range b {
+		seen[n] = true
+	}
+	var out []string
+	for _, n := range a {
+		if !seen[n] {
+			out = append(out, n)
+		}
+	}
+	return out
+}
@@ -0,0 +1,183 @@
+package history
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/luuuc/council/internal/review"
+)
+
+// inTempCouncil runs the test from an empty temp directory with a .council/.
+func inTempCouncil(t *testing.T) {
+	t.Helper()
+	tmp := t.TempDir()
+	origDir, _ := os.Getwd()
+	if err := os.Chdir(tmp); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(origDir) })
+	if err := os.Mkdir(".council", 0755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRecordAndList(t *testing.T) {
+	inTempCouncil(t)
+
+	for _, v := range []review.Verdict{review.VerdictPass, review.VerdictBlock} {
+		e := NewEntry(&review.SynthesizedResult{Verdict: v}, "go", "api:anthropic/claude-sonnet-4-6", "claude-sonnet-4-6")
+		if err := Record(e); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+		time.Sleep(time.Millisecond) // distinct timestamps, distinct IDs
+	}
+
+	entries, err := List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].Result.Verdict != review.VerdictPass || entries[1].Result.Verdict != review.VerdictBlock {
+		t.Error("entries should be listed oldest first")
+	}
+	if entries[0].ID == entries[1].ID || len(entries[0].ID) != 8 {
+		t.Errorf("expected distinct 8-char IDs, got %q and %q", entries[0].ID, entries[1].ID)
+	}
+	if entries[0].Pack != "go" || entries[0].Model != "claude-sonnet-4-6" {
+		t.Errorf("metadata not recorded: %+v", entries[0])
+	}
+}
+
+func TestListNoHistory(t *testing.T) {
+	inTempCouncil(t)
+
+	entries, err := List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected no entries, got %d", len(entries))
+	}
+}
+
+func TestGet(t *testing.T) {
+	inTempCouncil(t)
+
+	first := &Entry{ID: "abc12345", Result: &review.SynthesizedResult{Verdict: review.VerdictPass}}
+	second := &Entry{ID: "abd99999", Result: &review.SynthesizedResult{Verdict: review.VerdictComment}}
+	for _, e := range []*Entry{first, second} {
+		if err := Record(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		id      string
+		want    string
+		wantErr bool
+	}{
+		{"abc12345", "abc12345", false},
+		{"abd", "abd99999", false},
+		{"latest", "abd99999", false},
+		{"ab", "", true},  // ambiguous
+		{"zzz", "", true}, // not found
+	}
+	for _, tt := range tests {
+		got, err := Get(tt.id)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Get(%q) expected error", tt.id)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Get(%q) error = %v", tt.id, err)
+			continue
+		}
+		if got.ID != tt.want {
+			t.Errorf("Get(%q) = %s, want %s", tt.id, got.ID, tt.want)
+		}
+	}
+}
+
+func TestLastReviewed(t *testing.T) {
+	inTempCouncil(t)
+
+	entries := []*Entry{
+		{ID: "e1", Branch: "feature", Pack: "go", Reviewed: "aaa"},
+		{ID: "e2", Branch: "feature", Pack: "go", Reviewed: "bbb"},
+		{ID: "e3", Branch: "feature", Pack: "go", Reviewed: "rewritten"},
+		{ID: "e4", Branch: "feature", Pack: "rails", Reviewed: "ccc"},
+		{ID: "e5", Branch: "main", Pack: "go", Reviewed: "ddd"},
+		{ID: "e6", Branch: "feature", Pack: "go"}, // --staged, no Reviewed
+	}
+	for _, e := range entries {
+		e.Result = &review.SynthesizedResult{Verdict: review.VerdictPass}
+		if err := Record(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	reachable := func(sha string) bool { return sha != "rewritten" }
+	got, err := LastReviewed("feature", "go", reachable)
+	if err != nil {
+		t.Fatalf("LastReviewed() error = %v", err)
+	}
+	if got == nil || got.ID != "e2" {
+		t.Errorf("LastReviewed() = %+v, want e2", got)
+	}
+
+	if got, _ := LastReviewed("other", "go", reachable); got != nil {
+		t.Errorf("LastReviewed() on an unreviewed branch = %+v, want nil", got)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	from := &Entry{ID: "a", Result: &review.SynthesizedResult{
+		Verdict: review.VerdictBlock,
+		Perspectives: []review.ExpertVerdict{
+			{Expert: "kent-beck", Verdict: review.VerdictBlock, Notes: []string{"No tests", "Long function"}},
+			{Expert: "bruce-schneier", Verdict: review.VerdictPass},
+			{Expert: "jason-fried", Verdict: review.VerdictComment},
+		},
+	}}
+	to := &Entry{ID: "b", Result: &review.SynthesizedResult{
+		Verdict: review.VerdictComment,
+		Perspectives: []review.ExpertVerdict{
+			{Expert: "kent-beck", Verdict: review.VerdictComment, Notes: []string{"Long function"},
+				Findings: []review.Finding{{File: "user_test.go", StartLine: 4, EndLine: 4, Message: "Test names unclear"}}},
+			{Expert: "bruce-schneier", Verdict: review.VerdictPass},
+			{Expert: "sandi-metz", Verdict: review.VerdictPass},
+		},
+	}}
+
+	cmp := Compare(from, to)
+
+	if len(cmp.Experts) != 4 {
+		t.Fatalf("expected 4 experts, got %d", len(cmp.Experts))
+	}
+
+	kent := cmp.Experts[0]
+	if kent.Expert != "kent-beck" || kent.From != review.VerdictBlock || kent.To != review.VerdictComment {
+		t.Errorf("unexpected kent-beck change: %+v", kent)
+	}
+	if len(kent.RemovedNotes) != 1 || kent.RemovedNotes[0] != "No tests" {
+		t.Errorf("expected 'No tests' removed, got %v", kent.RemovedNotes)
+	}
+	if len(kent.AddedNotes) != 1 || kent.AddedNotes[0] != "user_test.go:4: Test names unclear" {
+		t.Errorf("expected the test-names finding added, got %v", kent.AddedNotes)
+	}
+
+	if cmp.Experts[1].Changed() {
+		t.Errorf("bruce-schneier should be unchanged: %+v", cmp.Experts[1])
+	}
+	if cmp.Experts[2].Expert != "jason-fried" || cmp.Experts[2].To != "" {
+		t.Errorf("jason-fried should be absent from the newer review: %+v", cmp.Experts[2])
+	}
+	if cmp.Experts[3].Expert != "sandi-metz" || cmp.Experts[3].From != "" {
+		t.Errorf("sandi-metz should be new in the newer review: %+v", cmp.Experts[3])
+	}
+}
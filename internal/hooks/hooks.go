@@ -0,0 +1,145 @@
+// Package hooks installs and removes the git hooks that run council reviews
+// before commits and pushes.
+package hooks
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Marker identifies hook scripts written by council. Hooks without it
+// belong to the user or another tool and are never overwritten or removed
+// unless forced.
+const Marker = "# council-managed-hook"
+
+// BypassEnv skips council hooks when set to any non-empty value, e.g.
+// COUNCIL_SKIP_HOOKS=1 git commit. git --no-verify works too.
+const BypassEnv = "COUNCIL_SKIP_HOOKS"
+
+// Names lists the hooks council can manage.
+var Names = []string{"pre-commit", "pre-push"}
+
+// Options are the review settings baked into an installed hook.
+type Options struct {
+	Pack    string // pack to review with; "" uses the full council
+	FailOn  string // verdict threshold that fails the hook, see review.FailOn
+	Timeout int    // seconds before the hook gives up and lets git proceed
+}
+
+// State describes what is installed at a hook path.
+type State string
+
+const (
+	StateMissing State = "not installed"
+	StateManaged State = "installed"
+	StateForeign State = "other hook present"
+)
+
+// ValidName reports whether name is a hook council manages.
+func ValidName(name string) bool {
+	for _, n := range Names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Script returns the hook script for name. The script bails out early when
+// BypassEnv is set or council is not on PATH, so a missing binary never
+// blocks a commit.
+func Script(name string, opts Options) string {
+	var b strings.Builder
+	b.WriteString("#!/bin/sh\n")
+	b.WriteString(Marker + "\n")
+	b.WriteString("# Written by 'council hooks install'; remove with 'council hooks uninstall'.\n")
+	fmt.Fprintf(&b, "# Bypass with %s=1 or git --no-verify.\n\n", BypassEnv)
+	fmt.Fprintf(&b, "if [ -n \"$%s\" ]; then\n\texit 0\nfi\n", BypassEnv)
+	b.WriteString("if ! command -v council >/dev/null 2>&1; then\n")
+	b.WriteString("\techo \"council: not on PATH, skipping review\" >&2\n\texit 0\nfi\n\n")
+	b.WriteString(Command(name, opts) + "\n")
+	return b.String()
+}
+
+// Command returns the council invocation a hook script execs.
+func Command(name string, opts Options) string {
+	args := []string{"exec", "council", "hooks", "run", name}
+	if opts.Pack != "" {
+		args = append(args, "--pack", shellQuote(opts.Pack))
+	}
+	if opts.FailOn != "" {
+		args = append(args, "--fail-on", opts.FailOn)
+	}
+	if opts.Timeout > 0 {
+		args = append(args, "--timeout", fmt.Sprint(opts.Timeout))
+	}
+	return strings.Join(args, " ")
+}
+
+// shellQuote wraps s in single quotes for /bin/sh.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// Install writes the hook into dir. An existing hook that council did not
+// write is left alone unless force is set.
+func Install(dir, name string, opts Options, force bool) error {
+	if !ValidName(name) {
+		return fmt.Errorf("unknown hook '%s': must be one of: %s", name, strings.Join(Names, ", "))
+	}
+
+	state, _, err := Status(dir, name)
+	if err != nil {
+		return err
+	}
+	if state == StateForeign && !force {
+		return fmt.Errorf("%s already exists and was not written by council (use --force to replace it)", filepath.Join(dir, name))
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create hooks directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(Script(name, opts)), 0755); err != nil {
+		return fmt.Errorf("failed to write %s hook: %w", name, err)
+	}
+	return nil
+}
+
+// Uninstall removes the hook from dir if council wrote it. Returns whether
+// a hook was removed.
+func Uninstall(dir, name string) (bool, error) {
+	state, _, err := Status(dir, name)
+	if err != nil || state != StateManaged {
+		return false, err
+	}
+	if err := os.Remove(filepath.Join(dir, name)); err != nil {
+		return false, fmt.Errorf("failed to remove %s hook: %w", name, err)
+	}
+	return true, nil
+}
+
+// Status reports what is installed for name in dir. For managed hooks it
+// also returns the council command line the hook runs.
+func Status(dir, name string) (State, string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return StateMissing, "", nil
+		}
+		return "", "", fmt.Errorf("failed to read %s hook: %w", name, err)
+	}
+
+	content := string(data)
+	if !strings.Contains(content, Marker) {
+		return StateForeign, "", nil
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(line, "exec council ") {
+			return StateManaged, strings.TrimPrefix(line, "exec "), nil
+		}
+	}
+	return StateManaged, "", nil
+}
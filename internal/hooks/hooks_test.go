@@ -0,0 +1,95 @@
+package hooks
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestScript(t *testing.T) {
+	script := Script("pre-commit", Options{Pack: "go", FailOn: "block", Timeout: 90})
+
+	for _, want := range []string{
+		"#!/bin/sh\n",
+		Marker,
+		`if [ -n "$COUNCIL_SKIP_HOOKS" ]; then`,
+		"command -v council",
+		"exec council hooks run pre-commit --pack 'go' --fail-on block --timeout 90\n",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q\n\n%s", want, script)
+		}
+	}
+}
+
+func TestInstallStatusUninstall(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "hooks")
+
+	if state, _, err := Status(dir, "pre-commit"); err != nil || state != StateMissing {
+		t.Fatalf("Status() before install = %q, %v; want %q", state, err, StateMissing)
+	}
+
+	if err := Install(dir, "pre-commit", Options{FailOn: "comment"}, false); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	info, err := os.Stat(filepath.Join(dir, "pre-commit"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode()&0111 == 0 {
+		t.Error("hook should be executable")
+	}
+
+	state, command, err := Status(dir, "pre-commit")
+	if err != nil || state != StateManaged {
+		t.Fatalf("Status() after install = %q, %v; want %q", state, err, StateManaged)
+	}
+	if command != "council hooks run pre-commit --fail-on comment" {
+		t.Errorf("command = %q", command)
+	}
+
+	// Reinstalling over our own hook is fine
+	if err := Install(dir, "pre-commit", Options{}, false); err != nil {
+		t.Errorf("reinstall error = %v", err)
+	}
+
+	removed, err := Uninstall(dir, "pre-commit")
+	if err != nil || !removed {
+		t.Fatalf("Uninstall() = %v, %v; want true", removed, err)
+	}
+	if state, _, _ := Status(dir, "pre-commit"); state != StateMissing {
+		t.Errorf("Status() after uninstall = %q, want %q", state, StateMissing)
+	}
+}
+
+func TestInstallKeepsForeignHooks(t *testing.T) {
+	dir := t.TempDir()
+	foreign := filepath.Join(dir, "pre-push")
+	if err := os.WriteFile(foreign, []byte("#!/bin/sh\nmake lint\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Install(dir, "pre-push", Options{}, false); err == nil {
+		t.Error("Install() over a foreign hook should fail without force")
+	}
+	if removed, err := Uninstall(dir, "pre-push"); err != nil || removed {
+		t.Errorf("Uninstall() of a foreign hook = %v, %v; want false", removed, err)
+	}
+	if data, _ := os.ReadFile(foreign); !strings.Contains(string(data), "make lint") {
+		t.Error("foreign hook should be untouched")
+	}
+
+	if err := Install(dir, "pre-push", Options{}, true); err != nil {
+		t.Fatalf("Install() with force error = %v", err)
+	}
+	if state, _, _ := Status(dir, "pre-push"); state != StateManaged {
+		t.Errorf("Status() after forced install = %q, want %q", state, StateManaged)
+	}
+}
+
+func TestInstallUnknownHook(t *testing.T) {
+	if err := Install(t.TempDir(), "post-merge", Options{}, false); err == nil {
+		t.Error("Install() of an unmanaged hook name should fail")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log"
 
 	"github.com/luuuc/council/internal/config"
 	"github.com/luuuc/council/internal/review"
@@ -103,11 +104,14 @@ type toolContent struct {
 
 // Server is the MCP server that reads JSON-RPC from reader and writes to writer.
 type Server struct {
-	reader  io.Reader
-	writer  io.Writer
-	config  *config.Config
-	backend review.Backend
-	version string
+	reader   io.Reader
+	writer   io.Writer
+	config   *config.Config
+	backend  review.Backend
+	backends *review.Registry // backends experts override the default with, see registry
+	build    review.BackendFactory
+	builder  *review.Builder // builds the default backend, and the others unless build is set
+	version  string
 }
 
 // Option configures a Server.
@@ -118,6 +122,12 @@ func WithBackend(b review.Backend) Option {
 	return func(s *Server) { s.backend = b }
 }
 
+// WithBackends sets how the backends experts and pack members ask for are
+// built (useful for testing).
+func WithBackends(build review.BackendFactory) Option {
+	return func(s *Server) { s.build = build }
+}
+
 // NewServer creates an MCP server that communicates over the given reader/writer.
 func NewServer(r io.Reader, w io.Writer, version string, opts ...Option) *Server {
 	s := &Server{
@@ -268,34 +278,44 @@ func (s *Server) getBackend() (review.Backend, error) {
 		return s.backend, nil
 	}
 
-	cfg, err := s.loadConfig()
+	b, err := s.buildBackend(review.BackendSpec{})
 	if err != nil {
-		return nil, fmt.Errorf("failed to load config: %w", err)
+		return nil, err
 	}
+	s.backend = b
+	return b, nil
+}
 
-	backend, provider, model := cfg.DetectBackend()
-	switch backend {
-	case "api":
-		if provider == "" {
-			return nil, fmt.Errorf("api backend requires a provider (anthropic, openai, ollama)")
+// registry returns the registry of backends experts and pack members ask
+// for, shared across tool calls so experts on the same model share its rate
+// limiting.
+func (s *Server) registry() *review.Registry {
+	if s.backends == nil {
+		build := s.build
+		if build == nil {
+			build = s.buildBackend
 		}
-		b, err := review.NewAPIBackend(provider, model)
+		s.backends = review.NewRegistry(build)
+	}
+	return s.backends
+}
+
+// buildBackend creates the backend config.yaml selects, with spec, an
+// expert's own backend, overriding it, chained to the ai.fallbacks.
+func (s *Server) buildBackend(spec review.BackendSpec) (review.Backend, error) {
+	if s.builder == nil {
+		cfg, err := s.loadConfig()
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("failed to load config: %w", err)
 		}
-		s.backend = b
-		return b, nil
-	case "cli":
-		aiCmd, err := cfg.DetectAICommand()
-		if err != nil {
-			return nil, err
+		s.builder = &review.Builder{
+			Config: cfg,
+			Skipped: func(spec review.BackendSpec, err error) {
+				log.Printf("skipping fallback %s: %v", spec, err)
+			},
 		}
-		b := review.NewCLIBackend(aiCmd, cfg.AI.Args)
-		s.backend = b
-		return b, nil
-	default:
-		return nil, fmt.Errorf("no backend available — install an AI CLI or set an API key")
 	}
+	return s.builder.Build(spec)
 }
 
 // toolDefinitions returns the MCP tool definitions for all council tools.
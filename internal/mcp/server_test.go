@@ -14,6 +14,8 @@ import (
 
 	"github.com/luuuc/council/internal/config"
 	"github.com/luuuc/council/internal/expert"
+	"github.com/luuuc/council/internal/history"
+	"github.com/luuuc/council/internal/pack"
 	"github.com/luuuc/council/internal/review"
 )
 
@@ -731,6 +733,131 @@ func TestToolsCallReviewHappyPath(t *testing.T) {
 	}
 }
 
+func TestToolsCallReviewAppliesPackPolicy(t *testing.T) {
+	cleanup := setupTestCouncil(t)
+	defer cleanup()
+
+	if err := pack.Save(&pack.Pack{
+		Name:    "gated",
+		Members: []pack.Member{{ID: "the-tdd-advocate"}, {ID: "the-go-purist"}},
+		Policy:  &review.Policy{Name: review.PolicyMajority},
+	}); err != nil {
+		t.Fatalf("save pack: %v", err)
+	}
+	backend := &mockBackend{results: map[string]review.ExpertVerdict{
+		"the-tdd-advocate": {Expert: "the-tdd-advocate", Verdict: review.VerdictBlock, Confidence: 0.9},
+	}}
+
+	input := sendRequest(1, "tools/call", toolCallParams{
+		Name:      "council_review",
+		Arguments: map[string]any{"pack": "gated", "content": "diff"},
+	}) + "\n"
+	output, err := runServer(input, backend)
+	if err != nil {
+		t.Fatalf("server error: %v", err)
+	}
+	resp, err := parseResponse(output)
+	if err != nil || resp.Error != nil {
+		t.Fatalf("response error: %v %v", err, resp.Error)
+	}
+
+	data, _ := json.Marshal(resp.Result)
+	var result toolCallResult
+	if err := json.Unmarshal(data, &result); err != nil || result.IsError {
+		t.Fatalf("tool error: %v %+v", err, result)
+	}
+	var verdict review.SynthesizedResult
+	if err := json.Unmarshal([]byte(result.Content[0].Text), &verdict); err != nil {
+		t.Fatalf("unmarshal verdict: %v", err)
+	}
+	if verdict.Policy != "majority" || verdict.Verdict != review.VerdictPass {
+		t.Errorf("verdict %s under policy %q, want pass under majority", verdict.Verdict, verdict.Policy)
+	}
+}
+
+// namedMockBackend is a mockBackend reporting a backend name.
+type namedMockBackend struct {
+	*mockBackend
+	name string
+}
+
+func (b namedMockBackend) Name() string { return b.name }
+
+func TestToolsCallReviewUsesMemberBackends(t *testing.T) {
+	cleanup := setupTestCouncil(t)
+	defer cleanup()
+
+	if err := pack.Save(&pack.Pack{
+		Name: "mixed",
+		Members: []pack.Member{
+			{ID: "the-tdd-advocate"},
+			{ID: "the-go-purist", BackendSpec: review.BackendSpec{Provider: "ollama", Model: "llama3"}},
+		},
+	}); err != nil {
+		t.Fatalf("save pack: %v", err)
+	}
+	local := namedMockBackend{mockBackend: &mockBackend{}, name: "api:ollama/llama3"}
+	var built []review.BackendSpec
+	build := func(spec review.BackendSpec) (review.Backend, error) {
+		built = append(built, spec)
+		return local, nil
+	}
+
+	input := sendRequest(1, "tools/call", toolCallParams{
+		Name:      "council_review",
+		Arguments: map[string]any{"pack": "mixed", "content": "diff"},
+	}) + "\n"
+	var out bytes.Buffer
+	srv := NewServer(strings.NewReader(input), &out, "test",
+		WithBackend(namedMockBackend{mockBackend: &mockBackend{}, name: "api:anthropic/claude-sonnet-4-6"}),
+		WithBackends(build))
+	srv.config = &config.Config{AI: config.AIConfig{Concurrency: 2, Timeout: 10}}
+	if err := srv.Run(context.Background()); err != nil {
+		t.Fatalf("server error: %v", err)
+	}
+
+	if len(built) != 1 || built[0].Provider != "ollama" || built[0].Model != "llama3" {
+		t.Errorf("built backends %+v, want the member's ollama/llama3", built)
+	}
+	if local.calls.Load() != 1 {
+		t.Errorf("member backend called %d times, want 1", local.calls.Load())
+	}
+
+	entries, err := history.List()
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("history: %v, %d entries", err, len(entries))
+	}
+	if got := entries[0].Model; !strings.Contains(got, "api:ollama/llama3") || !strings.Contains(got, "api:anthropic/claude-sonnet-4-6") {
+		t.Errorf("history model = %q, want both models", got)
+	}
+}
+
+func TestBuildBackendChainsFallbacks(t *testing.T) {
+	srv := NewServer(strings.NewReader(""), &bytes.Buffer{}, "test")
+	srv.config = &config.Config{AI: config.AIConfig{
+		Backend:   "api",
+		Provider:  "ollama",
+		Model:     "llama3",
+		Timeout:   30,
+		Fallbacks: []config.FallbackConfig{{Provider: "ollama", Model: "qwen2.5-coder"}},
+	}}
+
+	backend, err := srv.getBackend()
+	if err != nil {
+		t.Fatalf("getBackend() error = %v", err)
+	}
+	chain, ok := backend.(*review.FallbackBackend)
+	if !ok {
+		t.Fatalf("backend = %T, want the ai.fallbacks chain", backend)
+	}
+	if got := review.BackendName(chain); got != "api:ollama/llama3 → api:ollama/qwen2.5-coder" {
+		t.Errorf("chain = %q", got)
+	}
+	if chain.Timeout != 30*time.Second {
+		t.Errorf("per-backend timeout = %s, want ai.timeout", chain.Timeout)
+	}
+}
+
 func TestToolsCallExplainHappyPath(t *testing.T) {
 	cleanup := setupTestCouncil(t)
 	defer cleanup()
@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"text/template"
 
+	"github.com/luuuc/council/internal/config"
 	"github.com/luuuc/council/internal/expert"
+	"github.com/luuuc/council/internal/history"
 	"github.com/luuuc/council/internal/pack"
 	"github.com/luuuc/council/internal/review"
 )
@@ -44,6 +46,7 @@ func (s *Server) handleReview(ctx context.Context, args map[string]any) toolCall
 		inputs[i] = review.ExpertInput{
 			Expert:   rm.Expert,
 			Blocking: rm.Blocking,
+			Backend:  rm.Backend,
 		}
 	}
 
@@ -55,16 +58,42 @@ func (s *Server) handleReview(ctx context.Context, args map[string]any) toolCall
 		return errorResult(fmt.Sprintf("backend error: %v", err))
 	}
 
+	// Place every expert in the configured domain hierarchy
+	hierarchy, err := review.NewHierarchy(s.config.Domains)
+	if err != nil {
+		return errorResult(fmt.Sprintf("config error: %v", err))
+	}
+	members := make([]*expert.Expert, len(inputs))
+	for i, in := range inputs {
+		members[i] = in.Expert
+	}
+	hierarchy.Tag(members)
+
 	runner := &review.Runner{
 		Backend: backend,
 		Options: review.ReviewOptions{
 			Concurrency: s.config.AI.Concurrency,
 			Timeout:     s.config.AI.Timeout,
 		},
+		Backends: s.registry(),
+	}
+	if p.Policy != nil {
+		if err := p.Policy.Validate(); err != nil {
+			return errorResult(fmt.Sprintf("pack %q: %v", packName, err))
+		}
+		runner.Policy = *p.Policy
+	}
+	if config.Exists() {
+		runner.Cache = review.NewCache(config.Path(config.CacheDir))
 	}
 
 	result := runner.Run(ctx, inputs, sub)
 
+	if config.Exists() {
+		// History is best-effort; a failed write must not fail the tool call.
+		_ = history.Record(history.NewEntry(result, p.Name, review.BackendName(backend), review.ReviewModel(result, review.BackendModel(backend))))
+	}
+
 	data, err := review.FormatJSON(result)
 	if err != nil {
 		return errorResult(fmt.Sprintf("failed to marshal result: %v", err))
@@ -8,13 +8,17 @@ import (
 	"strings"
 
 	"github.com/luuuc/council/internal/config"
+	"github.com/luuuc/council/internal/review"
 	"gopkg.in/yaml.v3"
 )
 
-// Member represents an expert in a pack with their blocking status.
+// Member represents an expert in a pack with their blocking status, and
+// optionally the backend, provider or model the expert reviews with.
 type Member struct {
 	ID       string `yaml:"id" json:"id"`
 	Blocking bool   `yaml:"blocking,omitempty" json:"blocking,omitempty"`
+
+	review.BackendSpec `yaml:",inline"`
 }
 
 // Pack represents a reusable group of experts.
@@ -23,6 +27,13 @@ type Pack struct {
 	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
 	Members     []Member `yaml:"members" json:"members"`
 	Source      string   `yaml:"-" json:"source,omitempty"` // "builtin" or ""
+
+	// Review overrides the review.ignore / review.include lists from
+	// config.yaml when this pack is used.
+	Review *config.ReviewConfig `yaml:"review,omitempty" json:"review,omitempty"`
+
+	// Policy decides how the members' verdicts combine; strict when unset.
+	Policy *review.Policy `yaml:"policy,omitempty" json:"policy,omitempty"`
 }
 
 // Validate checks that a pack has required fields.
@@ -33,6 +44,16 @@ func (p *Pack) Validate() error {
 	if strings.ContainsAny(p.Name, " /\\") {
 		return fmt.Errorf("pack name must not contain spaces or slashes")
 	}
+	if p.Policy != nil {
+		if err := p.Policy.Validate(); err != nil {
+			return fmt.Errorf("pack '%s': %w", p.Name, err)
+		}
+	}
+	for _, m := range p.Members {
+		if err := m.BackendSpec.Validate(); err != nil {
+			return fmt.Errorf("pack '%s', member '%s': %w", p.Name, m.ID, err)
+		}
+	}
 	return nil
 }
 
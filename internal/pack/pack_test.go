@@ -3,7 +3,11 @@ package pack
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
+
+	"github.com/luuuc/council/internal/config"
+	"github.com/luuuc/council/internal/review"
 )
 
 func TestParse(t *testing.T) {
@@ -31,6 +35,25 @@ members:
 				},
 			},
 		},
+		{
+			name: "member backend overrides",
+			input: `name: mixed
+members:
+  - id: the-threat-modeler
+    provider: anthropic
+    model: claude-opus-4-1
+  - id: the-go-purist
+    backend: api
+    provider: ollama
+`,
+			want: Pack{
+				Name: "mixed",
+				Members: []Member{
+					{ID: "the-threat-modeler", BackendSpec: review.BackendSpec{Provider: "anthropic", Model: "claude-opus-4-1"}},
+					{ID: "the-go-purist", BackendSpec: review.BackendSpec{Backend: "api", Provider: "ollama"}},
+				},
+			},
+		},
 		{
 			name: "minimal pack",
 			input: `name: minimal
@@ -42,6 +65,38 @@ members:
 				Members: []Member{{ID: "alice"}},
 			},
 		},
+		{
+			name: "review overrides",
+			input: `name: go
+members:
+  - id: rob-pike
+review:
+  ignore:
+    - go.sum
+    - "**/testdata/**"
+`,
+			want: Pack{
+				Name:    "go",
+				Members: []Member{{ID: "rob-pike"}},
+				Review:  &config.ReviewConfig{Ignore: []string{"go.sum", "**/testdata/**"}},
+			},
+		},
+		{
+			name: "policy shorthand",
+			input: `name: go
+policy: majority
+`,
+			want: Pack{Name: "go", Policy: &review.Policy{Name: review.PolicyMajority}},
+		},
+		{
+			name: "policy with settings",
+			input: `name: go
+policy:
+  name: quorum
+  quorum: 3
+`,
+			want: Pack{Name: "go", Policy: &review.Policy{Name: review.PolicyQuorum, Quorum: 3}},
+		},
 		{
 			name:  "empty members",
 			input: `name: empty`,
@@ -82,6 +137,12 @@ members:
 					t.Errorf("Members[%d].Blocking = %v, want %v", i, m.Blocking, tt.want.Members[i].Blocking)
 				}
 			}
+			if !reflect.DeepEqual(got.Review, tt.want.Review) {
+				t.Errorf("Review = %+v, want %+v", got.Review, tt.want.Review)
+			}
+			if !reflect.DeepEqual(got.Policy, tt.want.Policy) {
+				t.Errorf("Policy = %+v, want %+v", got.Policy, tt.want.Policy)
+			}
 		})
 	}
 }
@@ -115,6 +176,16 @@ func TestValidate(t *testing.T) {
 			name: "no members is valid",
 			pack: Pack{Name: "empty"},
 		},
+		{
+			name:    "unknown member provider",
+			pack:    Pack{Name: "go", Members: []Member{{ID: "rob-pike", BackendSpec: review.BackendSpec{Provider: "acme"}}}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown policy",
+			pack:    Pack{Name: "go", Policy: &review.Policy{Name: "loudest"}},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1,11 +1,16 @@
 package pack
 
-import "github.com/luuuc/council/internal/expert"
+import (
+	"github.com/luuuc/council/internal/expert"
+	"github.com/luuuc/council/internal/review"
+)
 
-// ResolvedMember pairs an expert with their blocking status in a pack.
+// ResolvedMember pairs an expert with their blocking status and backend
+// override in a pack.
 type ResolvedMember struct {
 	Expert   *expert.Expert
 	Blocking bool
+	Backend  review.BackendSpec
 }
 
 // Resolve matches pack members to available experts and enforces priority: always.
@@ -34,7 +39,7 @@ func Resolve(p *Pack, available []*expert.Expert) ([]ResolvedMember, []string) {
 			warnings = append(warnings, "expert '"+m.ID+"' not found")
 			continue
 		}
-		resolved = append(resolved, ResolvedMember{Expert: e, Blocking: m.Blocking})
+		resolved = append(resolved, ResolvedMember{Expert: e, Blocking: m.Blocking, Backend: m.BackendSpec})
 		included[e.ID] = true
 	}
 
@@ -1,14 +1,19 @@
 package review
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/luuuc/council/internal/expert"
 )
@@ -17,18 +22,38 @@ import (
 type APIBackend struct {
 	Provider string // "anthropic", "openai", "ollama", "github"
 	Model    string
+	Stream   bool        // request streamed responses and report progress as text arrives
+	Retry    RetryPolicy // retries for rate-limited and overloaded responses
 	client   *http.Client
 	config   providerConfig
+
+	// notBefore is shared by concurrent expert calls: when the provider says
+	// the rate-limit window is exhausted, every call waits for the reset.
+	mu        sync.Mutex
+	notBefore time.Time
 }
 
 // providerConfig captures the provider-specific API shape.
 type providerConfig struct {
-	URL         string
-	Headers     func() map[string]string // provider-specific headers (auth, versioning, etc.)
-	BuildBody   func(model, persona string) any
-	ExtractText func(respBody []byte) (string, error)
+	URL          string
+	Headers      func() map[string]string // provider-specific headers (auth, versioning, etc.)
+	BuildBody    func(model, persona string) map[string]any
+	ExtractText  func(respBody []byte) (string, error)
+	ExtractUsage func(body []byte) Usage                  // token counts of a response or stream event; zero when it carries none
+	StreamFormat streamFormat                             // wire format of streamed responses
+	StreamBody   map[string]any                           // extra request fields for streamed requests
+	ExtractDelta func(event []byte) (string, bool, error) // text delta and done flag from one stream event
+	OutputCap    string                                   // request field capping output tokens; empty when the provider takes none
 }
 
+// streamFormat is the framing of a streamed response body.
+type streamFormat int
+
+const (
+	streamSSE    streamFormat = iota // server-sent events: "data: {...}" lines
+	streamNDJSON                     // one JSON object per line
+)
+
 // maxResponseSize caps response body reads to prevent OOM from misbehaving APIs.
 const maxResponseSize = 1 << 20 // 1MB
 
@@ -41,12 +66,14 @@ func NewAPIBackend(provider, model string) (*APIBackend, error) {
 	return &APIBackend{
 		Provider: provider,
 		Model:    model,
+		Retry:    DefaultRetryPolicy,
 		client:   &http.Client{},
 		config:   cfg,
 	}, nil
 }
 
 // newAPIBackendWithClient is used by tests to inject a custom HTTP client.
+// Retries are off so error tests fail fast; set Retry to exercise them.
 func newAPIBackendWithClient(provider, model string, client *http.Client) (*APIBackend, error) {
 	cfg, err := providerFor(provider)
 	if err != nil {
@@ -65,6 +92,11 @@ func (b *APIBackend) SetBaseURL(url string) {
 	b.config.URL = url
 }
 
+// Name identifies the provider and model, e.g. "api:anthropic/claude-sonnet-4-6".
+func (b *APIBackend) Name() string {
+	return "api:" + b.Provider + "/" + b.Model
+}
+
 // Review executes a single expert review via the provider's API.
 func (b *APIBackend) Review(ctx context.Context, e *expert.Expert, sub Submission) (ExpertVerdict, error) {
 	prompt := sub.RawPrompt
@@ -72,7 +104,7 @@ func (b *APIBackend) Review(ctx context.Context, e *expert.Expert, sub Submissio
 		prompt = BuildPrompt(e, sub)
 	}
 
-	text, err := b.doRequest(ctx, prompt, e.ID, nil)
+	text, usage, err := b.doRequest(ctx, prompt, e.ID, nil)
 	if err != nil {
 		return ExpertVerdict{}, err
 	}
@@ -83,22 +115,20 @@ func (b *APIBackend) Review(ctx context.Context, e *expert.Expert, sub Submissio
 			Verdict:    VerdictComment,
 			Confidence: 1.0,
 			Notes:      []string{strings.TrimSpace(text)},
+			Usage:      usage,
 		}, nil
 	}
 
-	return ParseVerdict(e.ID, []byte(text)), nil
+	verdict := ParseVerdict(e.ID, []byte(text))
+	verdict.Usage = usage
+	return verdict, nil
 }
 
 // ReviewCollective executes a collective review with all experts via the provider's API.
 func (b *APIBackend) ReviewCollective(ctx context.Context, experts []*expert.Expert, sub Submission) (*SynthesizedResult, error) {
 	prompt := BuildCollectivePrompt(experts, sub)
 
-	var opts *requestOpts
-	if b.Provider == "anthropic" {
-		opts = &requestOpts{maxTokens: 4096}
-	}
-
-	text, err := b.doRequest(ctx, prompt, "collective", opts)
+	text, usage, err := b.doRequest(ctx, prompt, CollectiveLabel, &requestOpts{maxTokens: 4096})
 	if err != nil {
 		return nil, err
 	}
@@ -108,38 +138,128 @@ func (b *APIBackend) ReviewCollective(ctx context.Context, experts []*expert.Exp
 		expertIDs[i] = e.ID
 	}
 
-	return ParseCollectiveResult([]byte(text), expertIDs), nil
+	result := ParseCollectiveResult([]byte(text), expertIDs)
+	result.Usage = usage
+	return result, nil
 }
 
 // requestOpts allows callers to override provider defaults for a specific request.
 type requestOpts struct {
-	maxTokens int
+	maxTokens int // output cap; sent to anthropic, and the worst case a Budget reserves for
 }
 
-// doRequest sends a prompt to the provider API and returns the extracted text.
-func (b *APIBackend) doRequest(ctx context.Context, prompt, label string, opts *requestOpts) (string, error) {
-	buildBody := b.config.BuildBody
-	if opts != nil && opts.maxTokens > 0 && b.Provider == "anthropic" {
-		maxTok := opts.maxTokens
-		buildBody = func(model, persona string) any {
-			return map[string]any{
-				"model":      model,
-				"max_tokens": maxTok,
-				"messages": []map[string]string{
-					{"role": "user", "content": persona},
-				},
-			}
+// defaultMaxOutput is the output a Budget reserves for requests without
+// requestOpts.maxTokens: the anthropic per-expert cap.
+const defaultMaxOutput = 1024
+
+// doRequest sends a prompt to the provider API and returns the extracted
+// text and the call's token usage. When b.Stream is set the response is read
+// incrementally and progress is reported to the sink attached to ctx. When
+// ctx carries a Budget, the call is refused if it could go over it, and its
+// output is capped at what was reserved for it.
+func (b *APIBackend) doRequest(ctx context.Context, prompt, label string, opts *requestOpts) (string, *Usage, error) {
+	price, priced := PriceFor(b.Provider, b.Model)
+	budget := budgetFrom(ctx)
+	budgeted := budget != nil && priced
+
+	reqBody := b.config.BuildBody(b.Model, prompt)
+	maxOutput := defaultMaxOutput
+	if opts != nil && opts.maxTokens > 0 {
+		maxOutput = opts.maxTokens
+	}
+	// Anthropic requires the cap; other providers only get it when a budget
+	// counts on it
+	if b.config.OutputCap != "" && (b.Provider == "anthropic" || budgeted) {
+		reqBody[b.config.OutputCap] = maxOutput
+	}
+	if b.Stream {
+		reqBody["stream"] = true
+		for k, v := range b.config.StreamBody {
+			reqBody[k] = v
+		}
+	}
+
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return "", nil, fmt.Errorf("marshal request for %s: %w", label, err)
+	}
+
+	var reserved float64
+	if budgeted {
+		reserved = price.Cost(EstimateTokens(prompt), maxOutput)
+		if err := budget.reserve(reserved); err != nil {
+			return "", nil, fmt.Errorf("API call skipped for %s: %w", label, err)
 		}
 	}
 
-	body, err := json.Marshal(buildBody(b.Model, prompt))
+	text, usage, err := b.sendWithRetry(ctx, body, label)
 	if err != nil {
-		return "", fmt.Errorf("marshal request for %s: %w", label, err)
+		if budgeted {
+			budget.settle(reserved, 0)
+		}
+		return "", nil, err
+	}
+
+	if usage.InputTokens == 0 && usage.OutputTokens == 0 {
+		usage = Usage{InputTokens: EstimateTokens(prompt), OutputTokens: EstimateTokens(text), Estimated: true}
+	}
+	usage.Cost = price.Cost(usage.InputTokens, usage.OutputTokens)
+	usage.Unpriced = !priced
+	if budgeted {
+		budget.settle(reserved, usage.Cost)
+	}
+	return text, &usage, nil
+}
+
+// sendWithRetry sends body, retrying rate-limited and overloaded responses
+// under b.Retry.
+func (b *APIBackend) sendWithRetry(ctx context.Context, body []byte, label string) (string, Usage, error) {
+	for attempt := 0; ; attempt++ {
+		if err := b.waitForRateLimit(ctx); err != nil {
+			return "", Usage{}, fmt.Errorf("API call failed for %s: %w", label, err)
+		}
+
+		text, usage, err := b.send(ctx, body, label)
+		if err == nil {
+			return text, usage, nil
+		}
+
+		var hint time.Duration
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			if !apiErr.Retryable() {
+				return "", Usage{}, err
+			}
+			hint = apiErr.RetryAfter
+		} else if ctx.Err() != nil {
+			return "", Usage{}, err
+		}
+
+		if attempt+1 >= b.Retry.MaxAttempts {
+			return "", Usage{}, err
+		}
+
+		delay := b.Retry.backoff(attempt, hint)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return "", Usage{}, err // waiting would outlast the caller's timeout
+		}
+
+		reportProgress(ctx, ProgressEvent{Kind: ProgressRetrying, Expert: label, Err: err})
+		log.Printf("%s; retrying in %s (attempt %d of %d)", err, delay.Round(time.Millisecond), attempt+2, b.Retry.MaxAttempts)
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return "", Usage{}, fmt.Errorf("API call failed for %s: %w", label, ctx.Err())
+		}
 	}
+}
 
+// send performs one HTTP round trip. Non-200 responses are returned as *APIError.
+func (b *APIBackend) send(ctx context.Context, body []byte, label string) (string, Usage, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.config.URL, bytes.NewReader(body))
 	if err != nil {
-		return "", fmt.Errorf("create request for %s: %w", label, err)
+		return "", Usage{}, fmt.Errorf("create request for %s: %w", label, err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -151,26 +271,126 @@ func (b *APIBackend) doRequest(ctx context.Context, prompt, label string, opts *
 
 	resp, err := b.client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("API call failed for %s: %w", label, err)
+		return "", Usage{}, fmt.Errorf("API call failed for %s: %w", label, err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
+	if resp.StatusCode == http.StatusOK && rateLimitExhausted(resp.Header) {
+		b.deferUntil(time.Now().Add(rateLimitDelay(resp.Header, time.Now())))
+	}
+
+	if b.Stream && resp.StatusCode == http.StatusOK {
+		text, usage, err := b.readStream(ctx, resp.Body, label)
+		if err != nil {
+			return "", Usage{}, fmt.Errorf("read stream for %s: %w", label, err)
+		}
+		return text, usage, nil
+	}
+
 	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
 	if err != nil {
-		return "", fmt.Errorf("read response for %s: %w", label, err)
+		return "", Usage{}, fmt.Errorf("read response for %s: %w", label, err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		detail := truncateBytes(respBody, 200)
-		return "", fmt.Errorf("API returned %d for %s: %s", resp.StatusCode, label, detail)
+		apiErr := &APIError{
+			Kind:       classifyStatus(resp.StatusCode),
+			Provider:   b.Provider,
+			StatusCode: resp.StatusCode,
+			Label:      label,
+			Detail:     truncateBytes(respBody, 200),
+			RetryAfter: rateLimitDelay(resp.Header, time.Now()),
+		}
+		if apiErr.Kind == APIErrRateLimited {
+			b.deferUntil(time.Now().Add(apiErr.RetryAfter))
+		}
+		return "", Usage{}, apiErr
 	}
 
 	text, err := b.config.ExtractText(respBody)
 	if err != nil {
-		return "", fmt.Errorf("parse response for %s: %w", label, err)
+		return "", Usage{}, fmt.Errorf("parse response for %s: %w", label, err)
 	}
 
-	return text, nil
+	return text, b.config.ExtractUsage(respBody), nil
+}
+
+// deferUntil pushes back the earliest time the next request may be sent.
+func (b *APIBackend) deferUntil(t time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if t.After(b.notBefore) {
+		b.notBefore = t
+	}
+}
+
+// waitForRateLimit blocks until the shared rate-limit window allows another request.
+func (b *APIBackend) waitForRateLimit(ctx context.Context) error {
+	b.mu.Lock()
+	wait := time.Until(b.notBefore)
+	b.mu.Unlock()
+	if wait <= 0 {
+		return nil
+	}
+
+	select {
+	case <-time.After(wait):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// readStream accumulates text deltas from a streamed response body,
+// reporting the running byte count as ProgressStreaming events. Providers
+// report usage in one or several events, with running totals; the highest
+// counts seen are kept.
+func (b *APIBackend) readStream(ctx context.Context, body io.Reader, label string) (string, Usage, error) {
+	scanner := bufio.NewScanner(io.LimitReader(body, maxResponseSize))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxResponseSize)
+
+	var text strings.Builder
+	var usage Usage
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		event := line
+		if b.config.StreamFormat == streamSSE {
+			// Only data lines carry payloads; event:, id: and comments are framing.
+			data, ok := bytes.CutPrefix(line, []byte("data:"))
+			if !ok {
+				continue
+			}
+			event = bytes.TrimSpace(data)
+		}
+
+		delta, done, err := b.config.ExtractDelta(event)
+		if err != nil {
+			return "", Usage{}, err
+		}
+		if delta != "" {
+			text.WriteString(delta)
+			reportProgress(ctx, ProgressEvent{Kind: ProgressStreaming, Expert: label, Bytes: text.Len()})
+		}
+		u := b.config.ExtractUsage(event)
+		usage.InputTokens = max(usage.InputTokens, u.InputTokens)
+		usage.OutputTokens = max(usage.OutputTokens, u.OutputTokens)
+		if done {
+			return text.String(), usage, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", Usage{}, err
+	}
+
+	// Some providers close the stream without an explicit terminator.
+	if text.Len() == 0 {
+		return "", Usage{}, fmt.Errorf("stream ended without content")
+	}
+	return text.String(), usage, nil
 }
 
 // --- Anthropic provider ---
@@ -184,7 +404,7 @@ func anthropicProvider() providerConfig {
 				"anthropic-version": "2023-06-01",
 			}
 		},
-		BuildBody: func(model, persona string) any {
+		BuildBody: func(model, persona string) map[string]any {
 			return map[string]any{
 				"model":      model,
 				"max_tokens": 1024,
@@ -207,12 +427,55 @@ func anthropicProvider() providerConfig {
 			}
 			return resp.Content[0].Text, nil
 		},
+		ExtractUsage: func(body []byte) Usage {
+			// Responses and message_delta events carry usage at the top
+			// level; message_start nests it in the message.
+			var resp struct {
+				Usage   Usage `json:"usage"`
+				Message struct {
+					Usage Usage `json:"usage"`
+				} `json:"message"`
+			}
+			if err := json.Unmarshal(body, &resp); err != nil {
+				return Usage{}
+			}
+			return Usage{
+				InputTokens:  max(resp.Usage.InputTokens, resp.Message.Usage.InputTokens),
+				OutputTokens: max(resp.Usage.OutputTokens, resp.Message.Usage.OutputTokens),
+			}
+		},
+		StreamFormat: streamSSE,
+		OutputCap:    "max_tokens",
+		ExtractDelta: func(event []byte) (string, bool, error) {
+			var ev struct {
+				Type  string `json:"type"`
+				Delta struct {
+					Text string `json:"text"`
+				} `json:"delta"`
+				Error struct {
+					Message string `json:"message"`
+				} `json:"error"`
+			}
+			if err := json.Unmarshal(event, &ev); err != nil {
+				return "", false, fmt.Errorf("unmarshal anthropic stream event: %w", err)
+			}
+			switch ev.Type {
+			case "content_block_delta":
+				return ev.Delta.Text, false, nil
+			case "message_stop":
+				return "", true, nil
+			case "error":
+				return "", false, fmt.Errorf("anthropic stream error: %s", ev.Error.Message)
+			default:
+				return "", false, nil
+			}
+		},
 	}
 }
 
 // --- OpenAI-compatible shared layer (used by openai and github providers) ---
 
-func openaiCompatBuildBody(model, persona string) any {
+func openaiCompatBuildBody(model, persona string) map[string]any {
 	return map[string]any{
 		"model": model,
 		"messages": []map[string]string{
@@ -238,6 +501,50 @@ func openaiCompatExtractText(respBody []byte) (string, error) {
 	return resp.Choices[0].Message.Content, nil
 }
 
+// openaiCompatExtractUsage reads the usage of a response, or of the last
+// event of a stream requested with stream_options.include_usage.
+func openaiCompatExtractUsage(body []byte) Usage {
+	var resp struct {
+		Usage *struct {
+			PromptTokens     int `json:"prompt_tokens"`
+			CompletionTokens int `json:"completion_tokens"`
+		} `json:"usage"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil || resp.Usage == nil {
+		return Usage{}
+	}
+	return Usage{InputTokens: resp.Usage.PromptTokens, OutputTokens: resp.Usage.CompletionTokens}
+}
+
+// openaiCompatStreamBody asks for usage in the last event of a stream.
+var openaiCompatStreamBody = map[string]any{"stream_options": map[string]any{"include_usage": true}}
+
+func openaiCompatExtractDelta(event []byte) (string, bool, error) {
+	if string(event) == "[DONE]" {
+		return "", true, nil
+	}
+	var ev struct {
+		Choices []struct {
+			Delta struct {
+				Content string `json:"content"`
+			} `json:"delta"`
+		} `json:"choices"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(event, &ev); err != nil {
+		return "", false, fmt.Errorf("unmarshal openai-compatible stream event: %w", err)
+	}
+	if ev.Error != nil {
+		return "", false, fmt.Errorf("openai-compatible stream error: %s", ev.Error.Message)
+	}
+	if len(ev.Choices) == 0 {
+		return "", false, nil
+	}
+	return ev.Choices[0].Delta.Content, false, nil
+}
+
 // --- OpenAI provider ---
 
 func openaiProvider() providerConfig {
@@ -248,8 +555,13 @@ func openaiProvider() providerConfig {
 				"Authorization": "Bearer " + os.Getenv("OPENAI_API_KEY"),
 			}
 		},
-		BuildBody:   openaiCompatBuildBody,
-		ExtractText: openaiCompatExtractText,
+		BuildBody:    openaiCompatBuildBody,
+		ExtractText:  openaiCompatExtractText,
+		ExtractUsage: openaiCompatExtractUsage,
+		StreamFormat: streamSSE,
+		StreamBody:   openaiCompatStreamBody,
+		ExtractDelta: openaiCompatExtractDelta,
+		OutputCap:    "max_completion_tokens",
 	}
 }
 
@@ -263,8 +575,13 @@ func githubProvider() providerConfig {
 				"Authorization": "Bearer " + os.Getenv("GITHUB_TOKEN"),
 			}
 		},
-		BuildBody:   openaiCompatBuildBody,
-		ExtractText: openaiCompatExtractText,
+		BuildBody:    openaiCompatBuildBody,
+		ExtractText:  openaiCompatExtractText,
+		ExtractUsage: openaiCompatExtractUsage,
+		StreamFormat: streamSSE,
+		StreamBody:   openaiCompatStreamBody,
+		ExtractDelta: openaiCompatExtractDelta,
+		OutputCap:    "max_tokens",
 	}
 }
 
@@ -274,7 +591,7 @@ func ollamaProvider() providerConfig {
 	return providerConfig{
 		URL:     "http://localhost:11434/api/chat",
 		Headers: nil, // no auth
-		BuildBody: func(model, persona string) any {
+		BuildBody: func(model, persona string) map[string]any {
 			return map[string]any{
 				"model":  model,
 				"stream": false,
@@ -294,6 +611,34 @@ func ollamaProvider() providerConfig {
 			}
 			return resp.Message.Content, nil
 		},
+		ExtractUsage: func(body []byte) Usage {
+			// Set on the response, and on the final event of a stream.
+			var resp struct {
+				PromptEvalCount int `json:"prompt_eval_count"`
+				EvalCount       int `json:"eval_count"`
+			}
+			if err := json.Unmarshal(body, &resp); err != nil {
+				return Usage{}
+			}
+			return Usage{InputTokens: resp.PromptEvalCount, OutputTokens: resp.EvalCount}
+		},
+		StreamFormat: streamNDJSON,
+		ExtractDelta: func(event []byte) (string, bool, error) {
+			var ev struct {
+				Message struct {
+					Content string `json:"content"`
+				} `json:"message"`
+				Done  bool   `json:"done"`
+				Error string `json:"error"`
+			}
+			if err := json.Unmarshal(event, &ev); err != nil {
+				return "", false, fmt.Errorf("unmarshal ollama stream event: %w", err)
+			}
+			if ev.Error != "" {
+				return "", false, fmt.Errorf("ollama stream error: %s", ev.Error)
+			}
+			return ev.Message.Content, ev.Done, nil
+		},
 	}
 }
 
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -601,3 +602,154 @@ func TestAPIBackendRunnerIntegration(t *testing.T) {
 		t.Errorf("unexpected errors: %v", result.Errors)
 	}
 }
+
+// splitChunks breaks s into pieces of at most n bytes to simulate streamed deltas.
+func splitChunks(s string, n int) []string {
+	var chunks []string
+	for len(s) > n {
+		chunks = append(chunks, s[:n])
+		s = s[n:]
+	}
+	return append(chunks, s)
+}
+
+func TestAPIBackendStreamAnthropic(t *testing.T) {
+	verdictJSON := `{"expert":"test-expert","verdict":"block","confidence":0.9,"notes":["SQL injection"],"blocking":true}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		if body["stream"] != true {
+			t.Errorf("expected stream=true in request, got %v", body["stream"])
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "event: message_start\ndata: {\"type\":\"message_start\"}\n\n")
+		for _, chunk := range splitChunks(verdictJSON, 16) {
+			ev, _ := json.Marshal(map[string]any{
+				"type":  "content_block_delta",
+				"delta": map[string]string{"type": "text_delta", "text": chunk},
+			})
+			_, _ = io.WriteString(w, "event: content_block_delta\ndata: "+string(ev)+"\n\n")
+		}
+		_, _ = io.WriteString(w, "event: message_stop\ndata: {\"type\":\"message_stop\"}\n\n")
+	}))
+	defer server.Close()
+
+	t.Setenv("ANTHROPIC_API_KEY", "test-key")
+
+	backend, err := newAPIBackendWithClient("anthropic", "claude-sonnet-4-6", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend.SetBaseURL(server.URL)
+	backend.Stream = true
+
+	var streamed atomic.Int32
+	ctx := withProgress(context.Background(), func(ev ProgressEvent) {
+		if ev.Kind == ProgressStreaming && ev.Expert == "test-expert" {
+			streamed.Add(1)
+		}
+	})
+
+	verdict, err := backend.Review(ctx, testExpert(), testSubmission())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if verdict.Verdict != VerdictBlock {
+		t.Errorf("expected block, got %s", verdict.Verdict)
+	}
+	if len(verdict.Notes) != 1 || verdict.Notes[0] != "SQL injection" {
+		t.Errorf("unexpected notes: %v", verdict.Notes)
+	}
+	if got := int(streamed.Load()); got != len(splitChunks(verdictJSON, 16)) {
+		t.Errorf("expected one streaming event per delta, got %d", got)
+	}
+}
+
+func TestAPIBackendStreamOpenAICompatible(t *testing.T) {
+	collectiveJSON := `{"verdict":"comment","blocking":false,"perspectives":[{"expert":"expert-a","verdict":"comment","confidence":0.8,"notes":["Add test"],"blocking":false}],"agreements":[],"tension":"","summary":"Needs tests."}`
+
+	for _, provider := range []string{"openai", "github"} {
+		t.Run(provider, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/event-stream")
+				_, _ = io.WriteString(w, ": keep-alive\n\n")
+				for _, chunk := range splitChunks(collectiveJSON, 20) {
+					ev, _ := json.Marshal(map[string]any{
+						"choices": []map[string]any{{"delta": map[string]string{"content": chunk}}},
+					})
+					_, _ = io.WriteString(w, "data: "+string(ev)+"\n\n")
+				}
+				_, _ = io.WriteString(w, "data: [DONE]\n\n")
+			}))
+			defer server.Close()
+
+			backend, err := newAPIBackendWithClient(provider, "gpt-4o", server.Client())
+			if err != nil {
+				t.Fatal(err)
+			}
+			backend.SetBaseURL(server.URL)
+			backend.Stream = true
+
+			experts := []*expert.Expert{{ID: "expert-a", Name: "Expert A", Focus: "Testing"}}
+			result, err := backend.ReviewCollective(context.Background(), experts, testSubmission())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Verdict != VerdictComment || result.Summary != "Needs tests." {
+				t.Errorf("unexpected result: %+v", result)
+			}
+		})
+	}
+}
+
+func TestAPIBackendStreamOllama(t *testing.T) {
+	verdictJSON := `{"expert":"test-expert","verdict":"pass","confidence":0.6,"notes":[],"blocking":false}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		for _, chunk := range splitChunks(verdictJSON, 10) {
+			ev, _ := json.Marshal(map[string]any{"message": map[string]string{"content": chunk}, "done": false})
+			_, _ = w.Write(append(ev, '\n'))
+		}
+		_, _ = io.WriteString(w, `{"message":{"content":""},"done":true}`+"\n")
+	}))
+	defer server.Close()
+
+	backend, err := newAPIBackendWithClient("ollama", "llama3", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend.SetBaseURL(server.URL)
+	backend.Stream = true
+
+	verdict, err := backend.Review(context.Background(), testExpert(), testSubmission())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if verdict.Verdict != VerdictPass {
+		t.Errorf("expected pass, got %s", verdict.Verdict)
+	}
+}
+
+func TestAPIBackendStreamErrorEvent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "event: error\ndata: {\"type\":\"error\",\"error\":{\"type\":\"overloaded_error\",\"message\":\"Overloaded\"}}\n\n")
+	}))
+	defer server.Close()
+
+	backend, err := newAPIBackendWithClient("anthropic", "claude-sonnet-4-6", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend.SetBaseURL(server.URL)
+	backend.Stream = true
+
+	_, err = backend.Review(context.Background(), testExpert(), testSubmission())
+	if err == nil || !strings.Contains(err.Error(), "Overloaded") {
+		t.Errorf("expected stream error mentioning Overloaded, got %v", err)
+	}
+}
@@ -15,6 +15,35 @@ type Backend interface {
 	ReviewCollective(ctx context.Context, experts []*expert.Expert, sub Submission) (*SynthesizedResult, error)
 }
 
+// NamedBackend is implemented by backends that can identify the provider and
+// model producing their reviews (e.g. "api:anthropic/claude-sonnet-4-6").
+type NamedBackend interface {
+	Name() string
+}
+
+// BackendName returns b's name, or its Go type when it doesn't implement NamedBackend.
+func BackendName(b Backend) string {
+	if nb, ok := b.(NamedBackend); ok {
+		return nb.Name()
+	}
+	return fmt.Sprintf("%T", b)
+}
+
+// BackendModel returns the model b calls, or "" when the backend doesn't
+// choose one (a CLI uses whatever model it is configured with). For a
+// fallback chain, that is the primary backend's model.
+func BackendModel(b Backend) string {
+	switch b := b.(type) {
+	case *APIBackend:
+		return b.Model
+	case *FallbackBackend:
+		if len(b.Backends) > 0 {
+			return BackendModel(b.Backends[0])
+		}
+	}
+	return ""
+}
+
 // CLIBackend spawns subprocess calls to an AI CLI for reviews.
 type CLIBackend struct {
 	Command string
@@ -51,6 +80,11 @@ func NewCLIBackend(command string, args []string) *CLIBackend {
 	}
 }
 
+// Name identifies the CLI and its arguments, e.g. "cli:claude -p --output-format text".
+func (b *CLIBackend) Name() string {
+	return strings.TrimSpace("cli:" + b.Command + " " + strings.Join(b.Args, " "))
+}
+
 // Review executes a single expert review via subprocess.
 func (b *CLIBackend) Review(ctx context.Context, e *expert.Expert, sub Submission) (ExpertVerdict, error) {
 	prompt := sub.RawPrompt
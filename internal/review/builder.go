@@ -0,0 +1,133 @@
+package review
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/luuuc/council/internal/config"
+)
+
+// Builder builds the backends a review runs on from the ai section of
+// config.yaml. Both the CLI and the MCP server use it, so an expert's
+// backend, and the fallbacks it is chained to, come out the same in either.
+// Build is a BackendFactory, and is safe for concurrent use.
+type Builder struct {
+	Config *config.Config
+
+	// Override replaces the configured backend, provider and model, before
+	// an expert's own spec does: the CLI's --backend, --provider and
+	// --model.
+	Override BackendSpec
+
+	// Stream makes API backends stream their responses.
+	Stream bool
+
+	// Skipped is told about each ai.fallbacks entry that can't run here
+	// and is left out. May be nil.
+	Skipped func(spec BackendSpec, err error)
+
+	fallbacksOnce sync.Once
+	fallbacks     []Backend // ai.fallbacks, built once and shared by every chain
+	fallbacksErr  error
+}
+
+// Build creates the backend for spec, an expert's own backend, which
+// overrides Override, which overrides the configured one. With ai.fallbacks,
+// the backend is chained to them, each with ai.timeout of its own.
+func (b *Builder) Build(spec BackendSpec) (Backend, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Copy config to avoid mutating the caller's struct
+	overridden := *b.Config
+	if b.Override.Backend != "" {
+		overridden.AI.Backend = b.Override.Backend
+	}
+	if b.Override.Provider != "" {
+		overridden.AI.Provider = b.Override.Provider
+	}
+	if b.Override.Model != "" {
+		overridden.AI.Model = b.Override.Model
+	}
+	if spec.Provider != "" && spec.Provider != overridden.AI.Provider {
+		// Another provider: its default model, unless the spec names one
+		overridden.AI.Backend = "api"
+		overridden.AI.Provider = spec.Provider
+		overridden.AI.Model = ""
+	}
+	if spec.Backend != "" {
+		overridden.AI.Backend = spec.Backend
+	}
+	if spec.Model != "" {
+		overridden.AI.Model = spec.Model
+	}
+
+	primary, err := b.fromConfig(&overridden)
+	if err != nil {
+		return nil, err
+	}
+	fallbacks, err := b.Fallbacks()
+	if err != nil || len(fallbacks) == 0 {
+		return primary, err
+	}
+	chain := NewFallbackBackend(append([]Backend{primary}, fallbacks...)...)
+	chain.Timeout = time.Duration(b.Config.AI.Timeout) * time.Second
+	return chain, nil
+}
+
+// Fallbacks creates the backends ai.fallbacks lists on first use, telling
+// Skipped about and leaving out the ones that can't run here.
+func (b *Builder) Fallbacks() ([]Backend, error) {
+	b.fallbacksOnce.Do(func() {
+		for _, fc := range b.Config.AI.Fallbacks {
+			spec := BackendSpec{Backend: fc.Backend, Provider: fc.Provider, Model: fc.Model}
+			if err := spec.Validate(); err != nil {
+				b.fallbacks, b.fallbacksErr = nil, fmt.Errorf("ai.fallbacks: %w", err)
+				return
+			}
+			fallback := *b.Config
+			fallback.AI.Backend, fallback.AI.Provider, fallback.AI.Model = fc.Backend, fc.Provider, fc.Model
+			if fc.Backend == "" {
+				fallback.AI.Backend = "api"
+			}
+			backend, err := b.fromConfig(&fallback)
+			if err != nil {
+				if b.Skipped != nil {
+					b.Skipped(spec, err)
+				}
+				continue
+			}
+			b.fallbacks = append(b.fallbacks, backend)
+		}
+	})
+	return b.fallbacks, b.fallbacksErr
+}
+
+// fromConfig creates the backend cfg.AI selects, detecting one from the
+// environment when it names none.
+func (b *Builder) fromConfig(cfg *config.Config) (Backend, error) {
+	backend, provider, model := cfg.DetectBackend()
+
+	switch backend {
+	case "api":
+		if provider == "" {
+			return nil, fmt.Errorf("api backend requires a provider (anthropic, openai, ollama, github)")
+		}
+		api, err := NewAPIBackend(provider, model)
+		if err != nil {
+			return nil, err
+		}
+		api.Stream = b.Stream
+		return api, nil
+	case "cli":
+		aiCmd, err := cfg.DetectAICommand()
+		if err != nil {
+			return nil, err
+		}
+		return NewCLIBackend(aiCmd, cfg.AI.Args), nil
+	default:
+		return nil, fmt.Errorf("no backend available\n\nInstall an AI CLI (claude, opencode) or set an API key (ANTHROPIC_API_KEY, OPENAI_API_KEY, GITHUB_TOKEN)")
+	}
+}
@@ -0,0 +1,69 @@
+package review
+
+import (
+	"testing"
+
+	"github.com/luuuc/council/internal/config"
+)
+
+func TestBuilderOverrides(t *testing.T) {
+	b := &Builder{
+		Config:   &config.Config{AI: config.AIConfig{Backend: "api", Provider: "ollama", Model: "llama3"}},
+		Override: BackendSpec{Model: "qwen2.5-coder"},
+		Stream:   true,
+	}
+
+	tests := []struct {
+		spec BackendSpec
+		want string
+	}{
+		{BackendSpec{}, "api:ollama/qwen2.5-coder"},
+		{BackendSpec{Model: "mistral"}, "api:ollama/mistral"},
+		{BackendSpec{Provider: "openai"}, "api:openai/" + config.DefaultModels["openai"]},
+	}
+	for _, tt := range tests {
+		backend, err := b.Build(tt.spec)
+		if err != nil {
+			t.Fatalf("Build(%s) error = %v", tt.spec, err)
+		}
+		if got := BackendName(backend); got != tt.want {
+			t.Errorf("Build(%s) = %q, want %q", tt.spec, got, tt.want)
+		}
+		if api, ok := backend.(*APIBackend); !ok || !api.Stream {
+			t.Errorf("Build(%s) = %T, want a streaming API backend", tt.spec, backend)
+		}
+	}
+
+	if _, err := b.Build(BackendSpec{Backend: "cli", Provider: "openai"}); err == nil {
+		t.Error("an invalid spec should not build")
+	}
+}
+
+func TestBuilderSkipsFallbacks(t *testing.T) {
+	var skipped []string
+	b := &Builder{
+		Config: &config.Config{AI: config.AIConfig{
+			Backend:  "api",
+			Provider: "ollama",
+			Model:    "llama3",
+			Fallbacks: []config.FallbackConfig{
+				{Backend: "api"},
+				{Provider: "ollama", Model: "qwen2.5-coder"},
+			},
+		}},
+		Skipped: func(spec BackendSpec, err error) { skipped = append(skipped, spec.String()) },
+	}
+
+	for range 2 {
+		backend, err := b.Build(BackendSpec{})
+		if err != nil {
+			t.Fatalf("Build() error = %v", err)
+		}
+		if got := BackendName(backend); got != "api:ollama/llama3 → api:ollama/qwen2.5-coder" {
+			t.Errorf("chain = %q", got)
+		}
+	}
+	if len(skipped) != 1 || skipped[0] != "api:" {
+		t.Errorf("skipped %v, want the fallback without a provider, once", skipped)
+	}
+}
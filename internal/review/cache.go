@@ -0,0 +1,171 @@
+package review
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Cache is a content-addressed store of review results on disk.
+// Entries are keyed by everything that determines a result — submission,
+// expert bodies and blocking flags, backend, model and prompt version — so a
+// hit is a rerun of the exact same review.
+type Cache struct {
+	Dir string
+}
+
+// NewCache returns a Cache rooted at dir. The directory is created on first write.
+func NewCache(dir string) *Cache {
+	return &Cache{Dir: dir}
+}
+
+// CacheStats summarizes the cache contents.
+type CacheStats struct {
+	Entries int       `json:"entries"`
+	Bytes   int64     `json:"bytes"`
+	Oldest  time.Time `json:"oldest,omitempty"`
+	Newest  time.Time `json:"newest,omitempty"`
+}
+
+// CacheKey hashes the inputs that determine a review result.
+// backendName identifies the provider and model (see BackendName); of opts,
+// only the debate rounds and the chair change the result.
+func CacheKey(inputs []ExpertInput, sub Submission, backendName string, opts ReviewOptions) string {
+	h := sha256.New()
+	write := func(parts ...string) {
+		for _, p := range parts {
+			// Length-prefix each part so adjacent fields can't collide.
+			fmt.Fprintf(h, "%d:%s", len(p), p)
+		}
+	}
+
+	write(fmt.Sprintf("prompt-v%d", PromptVersion), backendName)
+	write(fmt.Sprintf("rounds=%d chair=%t", max(opts.Rounds, 1), opts.Chair))
+	write(sub.Content, sub.Context, sub.RawPrompt)
+	for _, inp := range inputs {
+		write(inp.Expert.ID, inp.Expert.Name, inp.Expert.Focus, inp.Expert.Body, fmt.Sprint(inp.Blocking))
+		if spec := inp.BackendSpec(); !spec.IsZero() {
+			write(spec.String())
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// path returns the file holding the entry for key.
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.Dir, key+".json")
+}
+
+// Get returns the cached result for key, if present and readable.
+func (c *Cache) Get(key string) (*SynthesizedResult, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	var result SynthesizedResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, false
+	}
+	return &result, true
+}
+
+// Put stores result under key. The write goes through a temp file so a
+// concurrent reader never sees a partial entry.
+func (c *Cache) Put(key string, result *SynthesizedResult) error {
+	if err := os.MkdirAll(c.Dir, 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	// Keep cache entries out of version control even when .council/ is committed.
+	ignore := filepath.Join(c.Dir, ".gitignore")
+	if _, err := os.Stat(ignore); os.IsNotExist(err) {
+		_ = os.WriteFile(ignore, []byte("*\n"), 0644)
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache entry: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(c.Dir, key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	return os.Rename(tmp.Name(), c.path(key))
+}
+
+// entries lists the cache entry files.
+func (c *Cache) entries() ([]os.DirEntry, error) {
+	all, err := os.ReadDir(c.Dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	var entries []os.DirEntry
+	for _, e := range all {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".json") {
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+// Stats reports the number, size and age range of cache entries.
+func (c *Cache) Stats() (CacheStats, error) {
+	entries, err := c.entries()
+	if err != nil {
+		return CacheStats{}, err
+	}
+
+	var stats CacheStats
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		stats.Entries++
+		stats.Bytes += info.Size()
+		mod := info.ModTime()
+		if stats.Oldest.IsZero() || mod.Before(stats.Oldest) {
+			stats.Oldest = mod
+		}
+		if mod.After(stats.Newest) {
+			stats.Newest = mod
+		}
+	}
+	return stats, nil
+}
+
+// Clear removes every cache entry and returns how many were removed.
+func (c *Cache) Clear() (int, error) {
+	entries, err := c.entries()
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, e := range entries {
+		if err := os.Remove(filepath.Join(c.Dir, e.Name())); err != nil {
+			return removed, fmt.Errorf("failed to remove %s: %w", e.Name(), err)
+		}
+		removed++
+	}
+	return removed, nil
+}
@@ -0,0 +1,170 @@
+package review
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/luuuc/council/internal/expert"
+)
+
+func cacheInputs() []ExpertInput {
+	return []ExpertInput{
+		{Expert: &expert.Expert{ID: "a", Name: "A", Focus: "Testing", Body: "Test everything."}},
+		{Expert: &expert.Expert{ID: "b", Name: "B", Focus: "Security", Body: "Trust nothing."}, Blocking: true},
+	}
+}
+
+func TestCacheKeyStable(t *testing.T) {
+	sub := Submission{Content: "diff", Context: "PR #1"}
+	k1 := CacheKey(cacheInputs(), sub, "api:anthropic/claude-sonnet-4-6", ReviewOptions{})
+	k2 := CacheKey(cacheInputs(), sub, "api:anthropic/claude-sonnet-4-6", ReviewOptions{})
+	if k1 != k2 {
+		t.Errorf("same inputs produced different keys: %s vs %s", k1, k2)
+	}
+}
+
+func TestCacheKeyChangesWithInputs(t *testing.T) {
+	base := CacheKey(cacheInputs(), Submission{Content: "diff"}, "api:anthropic/claude-sonnet-4-6", ReviewOptions{})
+
+	changedBody := cacheInputs()
+	changedBody[0].Expert.Body = "Test some things."
+
+	changedBlocking := cacheInputs()
+	changedBlocking[1].Blocking = false
+
+	variants := map[string]string{
+		"content":  CacheKey(cacheInputs(), Submission{Content: "other diff"}, "api:anthropic/claude-sonnet-4-6", ReviewOptions{}),
+		"model":    CacheKey(cacheInputs(), Submission{Content: "diff"}, "api:anthropic/claude-opus-4", ReviewOptions{}),
+		"provider": CacheKey(cacheInputs(), Submission{Content: "diff"}, "api:openai/claude-sonnet-4-6", ReviewOptions{}),
+		"body":     CacheKey(changedBody, Submission{Content: "diff"}, "api:anthropic/claude-sonnet-4-6", ReviewOptions{}),
+		"blocking": CacheKey(changedBlocking, Submission{Content: "diff"}, "api:anthropic/claude-sonnet-4-6", ReviewOptions{}),
+		"context":  CacheKey(cacheInputs(), Submission{Content: "diff", Context: "x"}, "api:anthropic/claude-sonnet-4-6", ReviewOptions{}),
+		"rounds":   CacheKey(cacheInputs(), Submission{Content: "diff"}, "api:anthropic/claude-sonnet-4-6", ReviewOptions{Rounds: 3}),
+		"chair":    CacheKey(cacheInputs(), Submission{Content: "diff"}, "api:anthropic/claude-sonnet-4-6", ReviewOptions{Chair: true}),
+	}
+	for name, key := range variants {
+		if key == base {
+			t.Errorf("changing %s did not change the cache key", name)
+		}
+	}
+
+	if CacheKey(cacheInputs(), Submission{Content: "diff"}, "api:anthropic/claude-sonnet-4-6", ReviewOptions{Rounds: 1, Concurrency: 8, Timeout: 30}) != base {
+		t.Error("a single round, concurrency and timeout should not change the cache key")
+	}
+}
+
+func TestCachePutGetStatsClear(t *testing.T) {
+	cache := NewCache(filepath.Join(t.TempDir(), "cache"))
+
+	if _, ok := cache.Get("missing"); ok {
+		t.Error("expected miss on empty cache")
+	}
+
+	stats, err := cache.Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Entries != 0 {
+		t.Errorf("expected 0 entries before the cache dir exists, got %d", stats.Entries)
+	}
+
+	for i := 0; i < 3; i++ {
+		result := &SynthesizedResult{Verdict: VerdictComment, Summary: fmt.Sprintf("review %d", i)}
+		if err := cache.Put(fmt.Sprintf("key%d", i), result); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, ok := cache.Get("key1")
+	if !ok {
+		t.Fatal("expected hit")
+	}
+	if got.Summary != "review 1" || got.Verdict != VerdictComment {
+		t.Errorf("unexpected cached result: %+v", got)
+	}
+
+	stats, err = cache.Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Entries != 3 || stats.Bytes == 0 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+
+	removed, err := cache.Clear()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 3 {
+		t.Errorf("expected 3 removed, got %d", removed)
+	}
+	if _, ok := cache.Get("key1"); ok {
+		t.Error("expected miss after clear")
+	}
+}
+
+func TestRunnerUsesCache(t *testing.T) {
+	backend := &MockBackend{}
+	runner := &Runner{
+		Backend: backend,
+		Options: ReviewOptions{Timeout: 10},
+		Cache:   NewCache(t.TempDir()),
+	}
+	sub := Submission{Content: "diff"}
+
+	first := runner.Run(context.Background(), cacheInputs(), sub)
+	if first.Cached {
+		t.Error("first run should not be served from cache")
+	}
+
+	second := runner.Run(context.Background(), cacheInputs(), sub)
+	if !second.Cached {
+		t.Error("second identical run should be served from cache")
+	}
+	if backend.collectiveCalls.Load() != 1 {
+		t.Errorf("expected backend to be called once, got %d", backend.collectiveCalls.Load())
+	}
+	if second.Summary != first.Summary || len(second.Perspectives) != len(first.Perspectives) {
+		t.Errorf("cached result differs from original: %+v vs %+v", second, first)
+	}
+}
+
+func TestRunnerCachedResultHasNoUsage(t *testing.T) {
+	usage := &Usage{InputTokens: 100, OutputTokens: 10, Cost: 0.001}
+	backend := &MockBackend{Results: map[string]ExpertVerdict{
+		"kent": {Expert: "kent", Verdict: VerdictPass, Usage: usage},
+	}}
+	runner := &Runner{Backend: backend, Cache: NewCache(t.TempDir())}
+	sub := Submission{Content: "diff"}
+
+	if first := runner.Run(context.Background(), debateInputs("kent"), sub); first.Usage == nil {
+		t.Fatal("first run should report its usage")
+	}
+	second := runner.Run(context.Background(), debateInputs("kent"), sub)
+	if !second.Cached || second.Usage != nil || second.Perspectives[0].Usage != nil {
+		t.Errorf("cached result usage = %+v / %+v, want none: nothing was spent", second.Usage, second.Perspectives[0].Usage)
+	}
+}
+
+func TestRunnerDoesNotCacheFailures(t *testing.T) {
+	dir := t.TempDir()
+	backend := &MockBackend{
+		Errors: map[string]error{"a": fmt.Errorf("timeout")},
+	}
+	runner := &Runner{
+		Backend: backend,
+		Options: ReviewOptions{Timeout: 10},
+		Cache:   NewCache(dir),
+	}
+
+	inputs := cacheInputs()[:1]
+	runner.Run(context.Background(), inputs, Submission{Content: "diff"})
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 0 {
+		t.Errorf("failed review should not be cached, found %d entries", len(entries))
+	}
+}
@@ -0,0 +1,154 @@
+package review
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"text/template"
+
+	"github.com/luuuc/council/internal/expert"
+)
+
+// ChairLabel is the Expert value of progress events for the chair's call.
+const ChairLabel = "chair"
+
+// chairExpert stands in for the chair in Backend.Review calls.
+var chairExpert = &expert.Expert{ID: ChairLabel, Name: "Chair"}
+
+var chairTemplate = template.Must(template.New("chair-prompt").Parse(`You chair a council of expert code reviewers. Each expert reviewed the submission on their own; their verdicts and findings are below. Write the council's synthesis.
+{{if .Context}}
+## Context
+
+{{.Context}}
+{{end}}
+## Verdicts
+{{range .Verdicts}}
+### {{.Expert}}: {{.Verdict}}, confidence {{.Confidence}}{{if .Blocking}} (blocking member){{end}}
+{{range .AllFindings}}
+- {{.String}}{{end}}
+{{end}}{{if .Debate}}
+## Debate
+
+The experts then read each other's verdicts and {{.Debate.Outcome}}:
+{{range .Debate.Positions}}
+- {{.}}{{end}}
+{{end}}
+## Response Format
+
+You MUST respond with ONLY a JSON object matching this exact schema. No markdown, no code fences, no explanation before or after.
+
+{"verdict":"<pass|comment|block|escalate>","agreements":["<point several experts make>"],"tension":"<the main disagreement, or empty>","summary":"<the council's conclusion>"}
+
+Field definitions:
+- verdict: the council's overall verdict. It can be no less severe than "{{.Floor}}", the most severe expert verdict; go higher only when the findings together call for it
+- agreements: the points the experts converge on, most important first, each naming who agrees
+- tension: where the experts disagree and how to weigh their positions; empty when they don't
+- summary: two to four sentences on what the council found and what the author should do next
+
+Respond with ONLY the JSON object. Nothing else.`))
+
+type chairData struct {
+	Context  string
+	Verdicts []ExpertVerdict
+	Debate   *Debate
+	Floor    Verdict
+}
+
+// BuildChairPrompt constructs the prompt asking the chair to synthesize a
+// council's verdicts. floor is the least severe verdict the chair may pick.
+func BuildChairPrompt(result *SynthesizedResult, sub Submission, floor Verdict) string {
+	var buf bytes.Buffer
+	data := chairData{Context: sub.Context, Verdicts: result.Perspectives, Debate: result.Debate, Floor: floor}
+	if err := chairTemplate.Execute(&buf, data); err != nil {
+		return "Summarize these code review verdicts as JSON with verdict, agreements, tension and summary."
+	}
+	return buf.String()
+}
+
+// ChairDecision is the chair's synthesis of a council's verdicts.
+type ChairDecision struct {
+	Verdict    Verdict  `json:"verdict"`
+	Agreements []string `json:"agreements"`
+	Tension    string   `json:"tension"`
+	Summary    string   `json:"summary"`
+}
+
+// ParseChairDecision extracts the chair's decision from its response, which
+// may be wrapped in a code fence or surrounded by prose.
+func ParseChairDecision(raw []byte) (ChairDecision, error) {
+	text := strings.TrimSpace(string(raw))
+	if fenced := extractFromCodeFence(text); fenced != "" {
+		text = fenced
+	}
+	if start := strings.IndexByte(text, '{'); start >= 0 {
+		if obj := extractBalancedJSON(text[start:]); obj != "" {
+			text = obj
+		}
+	}
+
+	var d ChairDecision
+	if err := json.Unmarshal([]byte(text), &d); err != nil {
+		return ChairDecision{}, fmt.Errorf("unparseable chair response: %s", truncate(text, 200))
+	}
+	d.Verdict = Verdict(strings.ToLower(string(d.Verdict)))
+	if !ValidVerdicts[d.Verdict] {
+		return ChairDecision{}, fmt.Errorf("chair returned unknown verdict %q", d.Verdict)
+	}
+	if d.Summary == "" {
+		return ChairDecision{}, fmt.Errorf("chair returned no summary")
+	}
+	return d, nil
+}
+
+// Apply replaces result's agreements, tension and summary with the chair's
+// and takes its verdict, unless that is less severe than ResolveOverallVerdict.
+// Blocking stays with ResolveBlocking: the chair can't unblock a review.
+func (d ChairDecision) Apply(result *SynthesizedResult) {
+	floor := ResolveOverallVerdict(result.Perspectives)
+	result.Verdict = d.Verdict
+	if floor.Severity() > d.Verdict.Severity() {
+		result.Verdict = floor
+	}
+	result.Blocking = ResolveBlocking(result.Perspectives)
+	result.Agreements = d.Agreements
+	result.Tension = d.Tension
+	result.Summary = d.Summary
+	result.Chaired = true
+}
+
+// runChair has the chair synthesize a per-expert result in one more call.
+// When there is nothing to chair or the call fails, the heuristic synthesis
+// stands.
+func (r *Runner) runChair(ctx context.Context, result *SynthesizedResult, inputs []ExpertInput, sub Submission) *SynthesizedResult {
+	if len(result.Perspectives) == 0 {
+		return result
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, r.callTimeout(r.Backend))
+	defer cancel()
+
+	prompt := BuildChairPrompt(result, sub, ResolveOverallVerdict(result.Perspectives))
+	reportProgress(ctx, ProgressEvent{Kind: ProgressStarted, Expert: ChairLabel})
+	reply, err := r.Backend.Review(callCtx, chairExpert, Submission{RawPrompt: prompt})
+	result.Usage = result.Usage.Plus(reply.Usage)
+	var decision ChairDecision
+	if err == nil {
+		if len(reply.Notes) == 0 {
+			err = fmt.Errorf("empty chair response")
+		} else {
+			decision, err = ParseChairDecision([]byte(reply.Notes[0]))
+		}
+	}
+	if err != nil {
+		reportProgress(ctx, ProgressEvent{Kind: ProgressFailed, Expert: ChairLabel, Err: err})
+		log.Printf("chair synthesis failed, keeping the heuristic synthesis: %s", err)
+		return result
+	}
+
+	decision.Apply(result)
+	reportProgress(ctx, ProgressEvent{Kind: ProgressFinished, Expert: ChairLabel, Verdict: result.Verdict})
+	return result
+}
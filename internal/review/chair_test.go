@@ -0,0 +1,161 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/luuuc/council/internal/expert"
+)
+
+// chairBackend returns verdicts for the experts and reply for the chair.
+type chairBackend struct {
+	MockBackend
+	reply    string
+	replyErr error
+	prompt   string
+}
+
+func (b *chairBackend) Review(ctx context.Context, e *expert.Expert, sub Submission) (ExpertVerdict, error) {
+	if e.ID != ChairLabel {
+		return b.MockBackend.Review(ctx, e, sub)
+	}
+	b.prompt = sub.RawPrompt
+	if b.replyErr != nil {
+		return ExpertVerdict{}, b.replyErr
+	}
+	return ExpertVerdict{Expert: e.ID, Verdict: VerdictComment, Notes: []string{b.reply}}, nil
+}
+
+func chairVerdicts() map[string]ExpertVerdict {
+	return map[string]ExpertVerdict{
+		"kent": {Expert: "kent", Verdict: VerdictComment, Confidence: 0.8, Findings: []Finding{{File: "a.go", StartLine: 4, Message: "missing test"}}},
+		"rob":  {Expert: "rob", Verdict: VerdictPass, Confidence: 0.9},
+	}
+}
+
+func TestRunChair(t *testing.T) {
+	backend := &chairBackend{
+		MockBackend: MockBackend{Results: chairVerdicts()},
+		reply:       `{"verdict":"block","agreements":["kent and rob agree the API is sound"],"tension":"kent wants tests first","summary":"Add the missing test before merging."}`,
+	}
+	runner := &Runner{Backend: backend, Options: ReviewOptions{Chair: true}}
+
+	result := runner.Run(context.Background(), debateInputs("kent", "rob"), Submission{Content: "diff", Context: "PR: add parser"})
+
+	if !result.Chaired {
+		t.Fatal("expected the chair's synthesis")
+	}
+	if backend.collectiveCalls.Load() != 0 {
+		t.Error("the chair reviews per expert, not collectively")
+	}
+	if result.Verdict != VerdictBlock || result.Summary != "Add the missing test before merging." || result.Tension != "kent wants tests first" {
+		t.Errorf("result = %s / %q / %q", result.Verdict, result.Summary, result.Tension)
+	}
+	for _, want := range []string{"PR: add parser", "### kent: comment", "- a.go:4: missing test", `no less severe than "comment"`} {
+		if !strings.Contains(backend.prompt, want) {
+			t.Errorf("chair prompt missing %q", want)
+		}
+	}
+}
+
+func TestRunChair_VerdictFloor(t *testing.T) {
+	verdicts := chairVerdicts()
+	verdicts["rob"] = ExpertVerdict{Expert: "rob", Verdict: VerdictBlock, Confidence: 0.9}
+	backend := &chairBackend{
+		MockBackend: MockBackend{Results: verdicts},
+		reply:       `{"verdict":"pass","agreements":[],"tension":"","summary":"Ship it."}`,
+	}
+	inputs := debateInputs("kent", "rob")
+	inputs[1].Blocking = true
+	runner := &Runner{Backend: backend, Options: ReviewOptions{Chair: true}}
+
+	result := runner.Run(context.Background(), inputs, Submission{Content: "diff"})
+
+	if result.Verdict != VerdictBlock {
+		t.Errorf("verdict = %s, the chair can't go below the experts' block", result.Verdict)
+	}
+	if !result.Blocking {
+		t.Error("the chair can't lift a blocking member's block")
+	}
+}
+
+func TestRunChair_StrictPolicyKeepsChairVerdict(t *testing.T) {
+	backend := &chairBackend{
+		MockBackend: MockBackend{Results: chairVerdicts()},
+		reply:       `{"verdict":"block","agreements":[],"tension":"","summary":"Add the missing test before merging."}`,
+	}
+	runner := &Runner{Backend: backend, Policy: Policy{Name: PolicyStrict}, Options: ReviewOptions{Chair: true}}
+
+	result := runner.Run(context.Background(), debateInputs("kent", "rob"), Submission{Content: "diff"})
+
+	if result.Verdict != VerdictBlock || result.Policy != "strict" {
+		t.Errorf("Run() = verdict %s, policy %q; a declared strict policy should keep the chair's block", result.Verdict, result.Policy)
+	}
+	if strings.Contains(result.Summary, "Resolved to") {
+		t.Errorf("the verdict didn't move, but the summary says so: %q", result.Summary)
+	}
+}
+
+func TestRunChair_FailureKeepsHeuristics(t *testing.T) {
+	for name, backend := range map[string]*chairBackend{
+		"call fails":  {MockBackend: MockBackend{Results: chairVerdicts()}, replyErr: errors.New("rate limited")},
+		"bad reply":   {MockBackend: MockBackend{Results: chairVerdicts()}, reply: "I think it's fine."},
+		"bad verdict": {MockBackend: MockBackend{Results: chairVerdicts()}, reply: `{"verdict":"lgtm","summary":"ok"}`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			runner := &Runner{Backend: backend, Options: ReviewOptions{Chair: true}}
+			result := runner.Run(context.Background(), debateInputs("kent", "rob"), Submission{Content: "diff"})
+
+			if result.Chaired {
+				t.Error("a failed chair call should leave the heuristic synthesis")
+			}
+			if result.Verdict != VerdictComment || result.Summary == "" || len(result.Errors) != 0 {
+				t.Errorf("result = %+v", result)
+			}
+		})
+	}
+}
+
+func TestRunChair_FailureNotCached(t *testing.T) {
+	backend := &chairBackend{MockBackend: MockBackend{Results: chairVerdicts()}, replyErr: errors.New("rate limited")}
+	runner := &Runner{Backend: backend, Options: ReviewOptions{Chair: true}, Cache: NewCache(t.TempDir())}
+	sub := Submission{Content: "diff"}
+
+	runner.Run(context.Background(), debateInputs("kent", "rob"), sub)
+
+	backend.replyErr = nil
+	backend.reply = `{"verdict":"comment","agreements":[],"tension":"","summary":"Add the test."}`
+	result := runner.Run(context.Background(), debateInputs("kent", "rob"), sub)
+
+	if result.Cached {
+		t.Fatal("a review whose chair failed should not be served from the cache")
+	}
+	if !result.Chaired || result.Summary != "Add the test." {
+		t.Errorf("result = %+v, want the chair's synthesis on the rerun", result)
+	}
+	if got := backend.calls.Load(); got != 4 {
+		t.Errorf("expert calls = %d, want 4: the rerun reviews again", got)
+	}
+}
+
+func TestParseChairDecision_Fenced(t *testing.T) {
+	raw := "Here is the synthesis:\n```json\n{\"verdict\":\"Comment\",\"agreements\":[\"a\"],\"tension\":\"\",\"summary\":\"Fine {mostly}.\"}\n```"
+
+	d, err := ParseChairDecision([]byte(raw))
+	if err != nil {
+		t.Fatalf("ParseChairDecision() error = %v", err)
+	}
+	if d.Verdict != VerdictComment || d.Summary != "Fine {mostly}." || len(d.Agreements) != 1 {
+		t.Errorf("decision = %+v", d)
+	}
+}
+
+func TestFormatHuman_Chaired(t *testing.T) {
+	result := &SynthesizedResult{Verdict: VerdictComment, Summary: "Add a test.", Chaired: true}
+
+	if out := FormatHuman(result, "", 2); !strings.Contains(out, "Chair: Add a test.\n") {
+		t.Errorf("output missing the chair's summary:\n%s", out)
+	}
+}
@@ -97,21 +97,44 @@ func SplitDiff(diff string, opts ChunkOptions) ChunkResult {
 	}
 }
 
-// MergeChunkedResults aggregates per-file SynthesizedResults into one overall result.
+// MergeChunkedResults aggregates per-file SynthesizedResults into one overall
+// result. The chair's summaries of the files it synthesized are kept in the
+// merged Summary, each labeled with its file.
 func MergeChunkedResults(results []*SynthesizedResult, skipped []FileDiff) *SynthesizedResult {
 	merged := &SynthesizedResult{
 		Verdict: VerdictPass,
 	}
 
+	reviewed, cached, unavailable := 0, 0, 0
+	var chaired []string
 	for _, r := range results {
 		if r == nil {
 			continue
 		}
+		reviewed++
+		if r.Cached {
+			cached++
+		}
+		if r.Unavailable {
+			unavailable++
+		}
+		if r.Chaired && r.Summary != "" {
+			chaired = append(chaired, chunkLabel(r)+r.Summary)
+		}
 		if r.Verdict.Severity() > merged.Verdict.Severity() {
 			merged.Verdict = r.Verdict
 		}
 		merged.Perspectives = append(merged.Perspectives, r.Perspectives...)
 		merged.Agreements = append(merged.Agreements, r.Agreements...)
+		merged.Clusters = append(merged.Clusters, r.Clusters...)
+		merged.Debate = merged.Debate.merge(r.Debate)
+		merged.Usage = merged.Usage.Plus(r.Usage)
+		if r.Policy != "" && !strings.Contains(merged.Policy, r.Policy) {
+			if merged.Policy != "" {
+				merged.Policy += "; "
+			}
+			merged.Policy += r.Policy
+		}
 		if r.Tension != "" {
 			if merged.Tension != "" {
 				merged.Tension += "\n\n"
@@ -124,17 +147,76 @@ func MergeChunkedResults(results []*SynthesizedResult, skipped []FileDiff) *Synt
 		merged.Errors = append(merged.Errors, r.Errors...)
 	}
 
+	merged.Cached = reviewed > 0 && cached == reviewed
+	merged.Unavailable = reviewed > 0 && unavailable == reviewed
+	merged.Chaired = len(chaired) > 0
+	merged.Summary = fmt.Sprintf("%d files reviewed per-file.", reviewed)
 	if len(skipped) > 0 {
-		var notes []string
-		for _, f := range skipped {
-			notes = append(notes, fmt.Sprintf("%s: %s", f.Path, f.SkipReason))
+		paths := make([]string, len(skipped))
+		for i, f := range skipped {
+			merged.Skipped = append(merged.Skipped, SkippedFile{Path: f.Path, Reason: f.SkipReason})
+			paths[i] = f.Path
 		}
-		merged.Summary = fmt.Sprintf("Skipped files:\n%s", strings.Join(notes, "\n"))
+		merged.Summary += fmt.Sprintf(" Skipped files: %s.", strings.Join(paths, ", "))
+	}
+	if len(chaired) > 0 {
+		merged.Summary += "\n" + strings.Join(chaired, "\n")
 	}
 
 	return merged
 }
 
+// chunkLabel returns "path: " for the file a per-file result covers, or ""
+// when its perspectives aren't tagged with one.
+func chunkLabel(r *SynthesizedResult) string {
+	for _, p := range r.Perspectives {
+		if p.File != "" {
+			return p.File + ": "
+		}
+	}
+	return ""
+}
+
+// DiffPaths returns the file paths in a unified diff, in diff order.
+func DiffPaths(diff string) []string {
+	files := parseDiffFiles(diff)
+	paths := make([]string, len(files))
+	for i, f := range files {
+		paths[i] = f.Path
+	}
+	return paths
+}
+
+// FilterDiff drops files from a unified diff. exclude returns the reason a
+// path should be left out, or "" to keep it. Returns the remaining diff and
+// the files removed, in diff order.
+func FilterDiff(diff string, exclude func(path string) string) (string, []SkippedFile) {
+	var kept strings.Builder
+	var removed []SkippedFile
+
+	for _, f := range parseDiffFiles(diff) {
+		if reason := exclude(f.Path); reason != "" {
+			removed = append(removed, SkippedFile{Path: f.Path, Reason: reason})
+			continue
+		}
+		kept.WriteString(f.Diff)
+	}
+
+	return kept.String(), removed
+}
+
+// IsDiff reports whether content looks like a unified git diff that
+// SplitDiff can break into per-file chunks.
+func IsDiff(content string) bool {
+	return strings.HasPrefix(content, "diff --git ") || strings.Contains(content, "\ndiff --git ")
+}
+
+// EstimatePromptOverhead approximates the tokens the expert personas and
+// prompt template add to every per-file call.
+func EstimatePromptOverhead(inputs []ExpertInput) int {
+	return (estimateCollectiveSize(inputs, Submission{}) + 2) / 3
+}
+
 // parseDiffFiles splits a unified diff into per-file FileDiff entries.
 func parseDiffFiles(diff string) []FileDiff {
 	if diff == "" {
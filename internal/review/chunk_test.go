@@ -242,6 +242,29 @@ func TestMergeChunkedResults(t *testing.T) {
 	}
 }
 
+func TestMergeChunkedResultsKeepsChairSummaries(t *testing.T) {
+	r1 := &SynthesizedResult{
+		Verdict:      VerdictComment,
+		Perspectives: []ExpertVerdict{{Expert: "a", Verdict: VerdictComment, File: "a.go"}},
+		Summary:      "Add a test for the parser.",
+		Chaired:      true,
+	}
+	r2 := &SynthesizedResult{
+		Verdict:      VerdictPass,
+		Perspectives: []ExpertVerdict{{Expert: "a", Verdict: VerdictPass, File: "b.go"}},
+		Summary:      "All experts pass.",
+	}
+
+	merged := MergeChunkedResults([]*SynthesizedResult{r1, r2}, nil)
+
+	if !merged.Chaired {
+		t.Error("merged should be chaired when a file was")
+	}
+	if want := "2 files reviewed per-file.\na.go: Add a test for the parser."; merged.Summary != want {
+		t.Errorf("summary = %q, want %q", merged.Summary, want)
+	}
+}
+
 func TestMergeChunkedResultsNilResults(t *testing.T) {
 	merged := MergeChunkedResults([]*SynthesizedResult{nil, nil}, nil)
 	if merged.Verdict != VerdictPass {
@@ -278,3 +301,73 @@ func makeDiffMixed(numSmall, smallChars, numLarge, largeChars int) string {
 	}
 	return b.String()
 }
+
+func TestMergeChunkedResultsRecordsSkipped(t *testing.T) {
+	skipped := []FileDiff{
+		{Path: "big.go", SkipReason: "file too large for per-file review"},
+		{Path: "extra.go", SkipReason: "reviewing 25 of 26 files, largest by diff size"},
+	}
+
+	merged := MergeChunkedResults([]*SynthesizedResult{{Verdict: VerdictPass}}, skipped)
+
+	if len(merged.Skipped) != 2 {
+		t.Fatalf("expected 2 skipped files, got %d", len(merged.Skipped))
+	}
+	if merged.Skipped[1].Path != "extra.go" || merged.Skipped[1].Reason != skipped[1].SkipReason {
+		t.Errorf("unexpected skipped entry: %+v", merged.Skipped[1])
+	}
+}
+
+func TestIsDiff(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{makeDiff(1, 10), true},
+		{"commit message\n\ndiff --git a/x.go b/x.go\n", true},
+		{"package main\n\nfunc main() {}\n", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := IsDiff(tt.input); got != tt.want {
+			t.Errorf("IsDiff(%q) = %v, want %v", truncate(tt.input, 30), got, tt.want)
+		}
+	}
+}
+
+func TestFilterDiff(t *testing.T) {
+	diff := `diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -1 +1,2 @@
+ package main
++func main() {}
+diff --git a/go.sum b/go.sum
+--- a/go.sum
++++ b/go.sum
+@@ -1 +1,2 @@
+ a v1.0.0 h1:x
++b v1.0.0 h1:y
+`
+
+	if got := DiffPaths(diff); len(got) != 2 || got[0] != "main.go" || got[1] != "go.sum" {
+		t.Fatalf("DiffPaths() = %v, want [main.go go.sum]", got)
+	}
+
+	kept, removed := FilterDiff(diff, func(path string) string {
+		if path == "go.sum" {
+			return "generated"
+		}
+		return ""
+	})
+
+	if strings.Contains(kept, "go.sum") {
+		t.Error("filtered diff should not contain go.sum")
+	}
+	if !strings.Contains(kept, "func main() {}") {
+		t.Error("filtered diff should keep main.go")
+	}
+	if len(removed) != 1 || removed[0].Path != "go.sum" || removed[0].Reason != "generated" {
+		t.Errorf("removed = %+v, want go.sum (generated)", removed)
+	}
+}
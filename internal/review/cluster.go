@@ -0,0 +1,278 @@
+package review
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// similarityThreshold is how alike two findings' messages must be, as the
+// share of words they have in common, to count as the same issue.
+const similarityThreshold = 0.6
+
+// lineSimilarityThreshold is the lower bar for findings on overlapping
+// lines of the same file: where they point already suggests the same issue,
+// but two unrelated problems on one line must stay apart.
+const lineSimilarityThreshold = 0.15
+
+// FindingCluster is one issue raised by several experts: a merged finding
+// and everyone who raised it.
+type FindingCluster struct {
+	Finding Finding  `json:"finding"`
+	Experts []string `json:"experts"` // in perspective order
+	Verdict Verdict  `json:"verdict"` // most severe verdict among them
+}
+
+// findingRef points to a finding: an index into the perspectives, and into
+// that perspective's AllFindings.
+type findingRef struct {
+	perspective, finding int
+}
+
+// ClusterFindings groups the findings different experts made about the same
+// issue: findings on overlapping lines of the same file whose messages share
+// some words, or whose messages are mostly the same words. Only clusters
+// raised by two or more experts are returned, in the order their first
+// finding appears. Each carries the most severe finding of the group, with
+// that severity made explicit.
+func ClusterFindings(verdicts []ExpertVerdict) []FindingCluster {
+	var clusters []FindingCluster
+	for _, group := range clusterRefs(verdicts) {
+		clusters = append(clusters, mergeCluster(verdicts, group))
+	}
+	return clusters
+}
+
+// clusterRefs returns the groups of findings ClusterFindings merges, each
+// with its most severe finding first.
+func clusterRefs(verdicts []ExpertVerdict) [][]findingRef {
+	var refs []findingRef
+	var findings []Finding
+	var words []map[string]bool
+	for i, v := range verdicts {
+		if v.Error != "" {
+			continue
+		}
+		for j, f := range v.AllFindings() {
+			refs = append(refs, findingRef{i, j})
+			findings = append(findings, f)
+			words = append(words, messageWords(f.Message))
+		}
+	}
+
+	// Union-find over every pair of findings from different experts
+	parent := make([]int, len(refs))
+	for i := range parent {
+		parent[i] = i
+	}
+	var root func(int) int
+	root = func(i int) int {
+		if parent[i] != i {
+			parent[i] = root(parent[i])
+		}
+		return parent[i]
+	}
+	for a := range refs {
+		for b := a + 1; b < len(refs); b++ {
+			if verdicts[refs[a].perspective].Expert == verdicts[refs[b].perspective].Expert {
+				continue
+			}
+			if sameIssue(findings[a], findings[b], words[a], words[b]) {
+				parent[root(b)] = root(a)
+			}
+		}
+	}
+
+	byRoot := make(map[int][]int)
+	var roots []int
+	for i := range refs {
+		r := root(i)
+		if _, ok := byRoot[r]; !ok {
+			roots = append(roots, r)
+		}
+		byRoot[r] = append(byRoot[r], i)
+	}
+
+	var groups [][]findingRef
+	for _, r := range roots {
+		members := byRoot[r]
+		experts := make(map[string]bool)
+		for _, i := range members {
+			experts[verdicts[refs[i].perspective].Expert] = true
+		}
+		if len(experts) < 2 {
+			continue
+		}
+
+		// Most severe finding first; earlier findings win ties
+		sort.SliceStable(members, func(x, y int) bool {
+			px, py := verdicts[refs[members[x]].perspective], verdicts[refs[members[y]].perspective]
+			return severityRank(effectiveSeverity(findings[members[x]], px.Verdict)) > severityRank(effectiveSeverity(findings[members[y]], py.Verdict))
+		})
+		group := make([]findingRef, len(members))
+		for k, i := range members {
+			group[k] = refs[i]
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// mergeCluster builds the FindingCluster for a group from clusterRefs.
+func mergeCluster(verdicts []ExpertVerdict, group []findingRef) FindingCluster {
+	lead := verdicts[group[0].perspective]
+	merged := lead.AllFindings()[group[0].finding]
+	merged.Severity = effectiveSeverity(merged, lead.Verdict)
+
+	byPerspective := make([]findingRef, len(group))
+	copy(byPerspective, group)
+	sort.SliceStable(byPerspective, func(i, j int) bool {
+		return byPerspective[i].perspective < byPerspective[j].perspective
+	})
+
+	c := FindingCluster{Finding: merged, Verdict: lead.Verdict}
+	seen := make(map[string]bool)
+	for _, ref := range byPerspective {
+		p := verdicts[ref.perspective]
+		if p.Verdict.Severity() > c.Verdict.Severity() {
+			c.Verdict = p.Verdict
+		}
+		if !seen[p.Expert] {
+			seen[p.Expert] = true
+			c.Experts = append(c.Experts, p.Expert)
+		}
+	}
+	return c
+}
+
+// sameIssue reports whether two findings are about the same thing. Findings
+// on different files never are.
+func sameIssue(a, b Finding, wordsA, wordsB map[string]bool) bool {
+	threshold := similarityThreshold
+	if a.File != "" && b.File != "" {
+		if a.File != b.File {
+			return false
+		}
+		if a.StartLine > 0 && b.StartLine > 0 && a.StartLine <= lastLine(b) && b.StartLine <= lastLine(a) {
+			threshold = lineSimilarityThreshold
+		}
+	}
+	return similarity(wordsA, wordsB) >= threshold
+}
+
+// lastLine returns the last line a finding covers.
+func lastLine(f Finding) int {
+	return max(f.StartLine, f.EndLine)
+}
+
+// stopWords are left out when comparing messages.
+var stopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "this": true, "that": true, "with": true,
+	"should": true, "could": true, "would": true, "here": true, "are": true,
+}
+
+// messageWords returns the distinct lowercase words of a message, ignoring
+// stop words and words shorter than three letters.
+func messageWords(msg string) map[string]bool {
+	words := make(map[string]bool)
+	for _, w := range strings.FieldsFunc(strings.ToLower(msg), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_')
+	}) {
+		if len(w) >= 3 && !stopWords[w] {
+			words[w] = true
+		}
+	}
+	return words
+}
+
+// similarity is the Jaccard index of two word sets: shared words over all
+// words. Empty sets are not similar to anything.
+func similarity(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for w := range a {
+		if b[w] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// severityRank orders finding severities, higher is more severe.
+func severityRank(s FindingSeverity) int {
+	switch s {
+	case SeverityError:
+		return 2
+	case SeverityWarning:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// clusterAgreements renders clusters as agreements, those raised by the
+// most experts first.
+func clusterAgreements(clusters []FindingCluster) []string {
+	if len(clusters) == 0 {
+		return nil
+	}
+	sorted := make([]FindingCluster, len(clusters))
+	copy(sorted, clusters)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return len(sorted[i].Experts) > len(sorted[j].Experts)
+	})
+
+	agreements := make([]string, len(sorted))
+	for i, c := range sorted {
+		agreements[i] = fmt.Sprintf("%d experts (%s) flag %s.", len(c.Experts), strings.Join(c.Experts, ", "), strings.TrimSuffix(c.Finding.String(), "."))
+	}
+	return agreements
+}
+
+// publishedFinding is a finding to post once: a lone expert's, or the
+// merged finding of a cluster with the most severe verdict and everyone
+// who raised it.
+type publishedFinding struct {
+	Perspective ExpertVerdict // who raised it; Verdict is the most severe in a cluster
+	Finding     Finding
+	Experts     []string
+}
+
+// Label names the experts behind the finding, e.g. "security, design".
+func (p publishedFinding) Label() string {
+	return strings.Join(p.Experts, ", ")
+}
+
+// publishFindings lists the findings of verdicts to post as comments, with
+// each cluster of findings on the same issue merged into one, placed where
+// its most severe finding would be.
+func publishFindings(verdicts []ExpertVerdict) []publishedFinding {
+	lead := make(map[findingRef]FindingCluster)
+	merged := make(map[findingRef]bool)
+	for _, group := range clusterRefs(verdicts) {
+		lead[group[0]] = mergeCluster(verdicts, group)
+		for _, ref := range group[1:] {
+			merged[ref] = true
+		}
+	}
+
+	var out []publishedFinding
+	for i, v := range verdicts {
+		for j, f := range v.AllFindings() {
+			ref := findingRef{i, j}
+			switch c, ok := lead[ref]; {
+			case merged[ref]:
+				// posted with its cluster's lead finding
+			case ok:
+				p := v
+				p.Verdict = c.Verdict
+				out = append(out, publishedFinding{Perspective: p, Finding: c.Finding, Experts: c.Experts})
+			default:
+				out = append(out, publishedFinding{Perspective: v, Finding: f, Experts: []string{v.Expert}})
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,114 @@
+package review
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestClusterFindings(t *testing.T) {
+	verdicts := []ExpertVerdict{
+		{Expert: "kent", Verdict: VerdictComment, Findings: []Finding{
+			{File: "user.go", StartLine: 12, EndLine: 12, Severity: SeverityWarning, Message: "user may be nil here"},
+			{File: "user.go", StartLine: 40, EndLine: 40, Message: "Rename this helper"},
+		}},
+		{Expert: "security", Verdict: VerdictBlock, Findings: []Finding{
+			{File: "user.go", StartLine: 10, EndLine: 13, Severity: SeverityError, Message: "Dereference without a nil check"},
+		}},
+		{Expert: "tests", Verdict: VerdictComment, Notes: []string{"user.go:12: Nil user is not handled"}},
+		{Expert: "broken", Verdict: VerdictComment, Error: "timeout", Notes: []string{"user.go:12: ignored"}},
+	}
+
+	clusters := ClusterFindings(verdicts)
+	if len(clusters) != 1 {
+		t.Fatalf("expected 1 cluster, got %+v", clusters)
+	}
+	c := clusters[0]
+	if strings.Join(c.Experts, ",") != "kent,security,tests" {
+		t.Errorf("Experts = %v, want kent,security,tests", c.Experts)
+	}
+	if c.Finding.Message != "Dereference without a nil check" || c.Finding.Severity != SeverityError {
+		t.Errorf("the most severe finding should lead the cluster, got %+v", c.Finding)
+	}
+	if c.Verdict != VerdictBlock {
+		t.Errorf("Verdict = %s, want block", c.Verdict)
+	}
+}
+
+func TestClusterFindingsBySimilarity(t *testing.T) {
+	verdicts := []ExpertVerdict{
+		{Expert: "kent", Verdict: VerdictComment, Notes: []string{"Missing error handling on the database call"}},
+		{Expert: "rob", Verdict: VerdictComment, Notes: []string{"Database call is missing error handling"}},
+		{Expert: "ada", Verdict: VerdictComment, Notes: []string{"Consider a table-driven test"}},
+	}
+
+	clusters := ClusterFindings(verdicts)
+	if len(clusters) != 1 || strings.Join(clusters[0].Experts, ",") != "kent,rob" {
+		t.Errorf("expected kent and rob clustered, got %+v", clusters)
+	}
+}
+
+func TestClusterFindingsKeepsApart(t *testing.T) {
+	tests := map[string][]ExpertVerdict{
+		"different files": {
+			{Expert: "kent", Verdict: VerdictComment, Findings: []Finding{{File: "a.go", StartLine: 3, Message: "Missing nil check"}}},
+			{Expert: "rob", Verdict: VerdictComment, Findings: []Finding{{File: "b.go", StartLine: 3, Message: "Missing nil check"}}},
+		},
+		"same expert": {
+			{Expert: "kent", Verdict: VerdictComment, Findings: []Finding{
+				{File: "a.go", StartLine: 3, Message: "Missing nil check"},
+				{File: "a.go", StartLine: 3, Message: "Missing nil check"},
+			}},
+		},
+		"unrelated lines": {
+			{Expert: "kent", Verdict: VerdictComment, Findings: []Finding{{File: "a.go", StartLine: 3, EndLine: 5, Message: "Missing nil check"}}},
+			{Expert: "rob", Verdict: VerdictComment, Findings: []Finding{{File: "a.go", StartLine: 6, Message: "Loop allocates on every pass"}}},
+		},
+		"unrelated issues on one line": {
+			{Expert: "kent", Verdict: VerdictComment, Findings: []Finding{{File: "a.go", StartLine: 3, EndLine: 5, Message: "Missing nil check"}}},
+			{Expert: "rob", Verdict: VerdictComment, Findings: []Finding{{File: "a.go", StartLine: 4, Message: "Loop allocates on every pass"}}},
+		},
+	}
+	for name, verdicts := range tests {
+		if clusters := ClusterFindings(verdicts); len(clusters) != 0 {
+			t.Errorf("%s: expected no clusters, got %+v", name, clusters)
+		}
+	}
+}
+
+func TestSynthesizeClusterAgreements(t *testing.T) {
+	verdicts := []ExpertVerdict{
+		{Expert: "kent", Verdict: VerdictComment, Notes: []string{"main.go:5: Missing nil check"}},
+		{Expert: "rob", Verdict: VerdictComment, Notes: []string{"main.go:5: No nil check before use"}},
+	}
+
+	result := Synthesize(verdicts, nil, nil)
+	if len(result.Clusters) != 1 {
+		t.Fatalf("expected 1 cluster, got %+v", result.Clusters)
+	}
+	if len(result.Agreements) != 2 || result.Agreements[0] != "2 experts (kent, rob) flag main.go:5: Missing nil check." {
+		t.Errorf("cluster agreement should come first, got %v", result.Agreements)
+	}
+}
+
+func TestFormatGitHubReviewMergesClusters(t *testing.T) {
+	result := &SynthesizedResult{
+		Verdict: VerdictBlock,
+		Perspectives: []ExpertVerdict{
+			{Expert: "kent", Verdict: VerdictComment, Notes: []string{"main.go:2: Missing nil check"}},
+			{Expert: "security", Verdict: VerdictBlock, Notes: []string{"main.go:2: Nil dereference"}},
+			{Expert: "rob", Verdict: VerdictComment, Notes: []string{"main.go:1: Rename x"}},
+		},
+	}
+	dp := NewDiffPosition("diff --git a/main.go b/main.go\n--- a/main.go\n+++ b/main.go\n@@ -0,0 +1,2 @@\n+x := load()\n+x.Run()\n")
+
+	out := FormatGitHubReview(result, "", 3, dp)
+	if len(out.Review.Comments) != 2 {
+		t.Fatalf("expected the two nil findings merged into one comment, got %+v", out.Review.Comments)
+	}
+	if body := out.Review.Comments[0].Body; !strings.HasPrefix(body, "**kent, security** (block):") {
+		t.Errorf("merged comment should name both experts and the strongest verdict:\n%s", body)
+	}
+	if len(out.CheckRun.Output.Annotations) != 2 {
+		t.Errorf("expected 2 annotations, got %d", len(out.CheckRun.Output.Annotations))
+	}
+}
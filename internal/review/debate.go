@@ -0,0 +1,148 @@
+package review
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Debate records a multi-round review: each expert's verdict per round, and
+// whether the council settled before running out of rounds.
+type Debate struct {
+	Rounds    int              `json:"rounds"`    // rounds actually run
+	Converged bool             `json:"converged"` // every expert answered the last round without changing its verdict
+	Positions []DebatePosition `json:"positions"`
+}
+
+// DebatePosition is one expert's verdict in each round it took part in.
+type DebatePosition struct {
+	Expert   string    `json:"expert"`
+	Verdicts []Verdict `json:"verdicts"`
+	File     string    `json:"file,omitempty"` // set in per-file review: the file debated
+}
+
+// Changed reports whether the expert's verdict moved during the debate.
+func (p DebatePosition) Changed() bool {
+	for _, v := range p.Verdicts {
+		if v != p.Verdicts[0] {
+			return true
+		}
+	}
+	return false
+}
+
+// String renders the position as "expert: block → comment", with the file
+// in per-file review.
+func (p DebatePosition) String() string {
+	steps := make([]string, len(p.Verdicts))
+	for i, v := range p.Verdicts {
+		steps[i] = string(v)
+	}
+	label := p.Expert
+	if p.File != "" {
+		label += " (" + p.File + ")"
+	}
+	return fmt.Sprintf("%s: %s", label, strings.Join(steps, " → "))
+}
+
+// Outcome summarizes the debate, e.g. "converged after 2 rounds".
+func (d *Debate) Outcome() string {
+	if d.Converged {
+		return fmt.Sprintf("converged after %d rounds", d.Rounds)
+	}
+	return fmt.Sprintf("still split after %d rounds", d.Rounds)
+}
+
+// merge combines the debates of a per-file review: the most rounds any
+// file took, converged only if every file did. Either side may be nil.
+func (d *Debate) merge(other *Debate) *Debate {
+	if other == nil {
+		return d
+	}
+	if d == nil {
+		d = &Debate{Converged: true}
+	}
+	d.Rounds = max(d.Rounds, other.Rounds)
+	d.Converged = d.Converged && other.Converged
+	d.Positions = append(d.Positions, other.Positions...)
+	return d
+}
+
+// runDebate reviews in up to Options.Rounds rounds. The first is a blind
+// per-expert pass; in each later round every expert sees the previous
+// round's verdicts (Submission.Debate) and may revise, concede or push back.
+// The debate stops early once every expert answered a round without
+// changing its verdict. An expert that fails in a later round keeps its last
+// verdict and the failure is reported in Errors; one that failed the blind
+// pass sits the debate out. The result is synthesized from the final
+// verdicts and records the debate.
+func (r *Runner) runDebate(ctx context.Context, inputs []ExpertInput, sub Submission) *SynthesizedResult {
+	results := r.reviewEach(ctx, inputs, 1, func(int) Submission { return sub })
+
+	debate := &Debate{Rounds: 1}
+	var roundErrors []string
+	positions := make([]DebatePosition, len(inputs))
+	for i, res := range results {
+		positions[i].Expert = inputs[i].Expert.ID
+		if res.err == nil {
+			positions[i].Verdicts = []Verdict{res.verdict.Verdict}
+		}
+	}
+
+	for round := 2; round <= r.Options.Rounds && ctx.Err() == nil; round++ {
+		var previous []ExpertVerdict
+		var active []int
+		for i, res := range results {
+			if res.err == nil {
+				previous = append(previous, res.verdict)
+				active = append(active, i)
+			}
+		}
+		if len(active) < 2 {
+			break // nobody left to answer
+		}
+
+		debaters := make([]ExpertInput, len(active))
+		for k, i := range active {
+			debaters[k] = inputs[i]
+		}
+		next := r.reviewEach(ctx, debaters, round, func(int) Submission {
+			s := sub
+			s.Debate = previous
+			return s
+		})
+
+		// Only a round every expert answered unmoved settles the debate
+		changed := false
+		for k, i := range active {
+			if next[k].err != nil {
+				changed = true
+				roundErrors = append(roundErrors, fmt.Sprintf("debate round %d: %s (kept its previous verdict)", round, next[k].err))
+				continue
+			}
+			if next[k].verdict.Verdict != results[i].verdict.Verdict {
+				changed = true
+			}
+			next[k].verdict.Usage = results[i].verdict.Usage.Plus(next[k].verdict.Usage)
+			results[i] = next[k]
+			positions[i].Verdicts = append(positions[i].Verdicts, next[k].verdict.Verdict)
+		}
+
+		debate.Rounds = round
+		if !changed {
+			debate.Converged = true
+			break
+		}
+	}
+
+	for _, p := range positions {
+		if len(p.Verdicts) > 0 {
+			debate.Positions = append(debate.Positions, p)
+		}
+	}
+
+	result := synthesizeResults(inputs, results)
+	result.Errors = append(result.Errors, roundErrors...)
+	result.Debate = debate
+	return result
+}
@@ -0,0 +1,181 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/luuuc/council/internal/expert"
+)
+
+// debateBackend answers each Review with respond, given the round: 1 for a
+// blind review, then one more than the rounds already debated.
+type debateBackend struct {
+	mu      sync.Mutex
+	respond func(e *expert.Expert, round int) (ExpertVerdict, error)
+	seen    map[string][]Submission
+}
+
+func (b *debateBackend) Review(ctx context.Context, e *expert.Expert, sub Submission) (ExpertVerdict, error) {
+	b.mu.Lock()
+	if b.seen == nil {
+		b.seen = make(map[string][]Submission)
+	}
+	b.seen[e.ID] = append(b.seen[e.ID], sub)
+	round := len(b.seen[e.ID])
+	b.mu.Unlock()
+	return b.respond(e, round)
+}
+
+func (b *debateBackend) ReviewCollective(ctx context.Context, experts []*expert.Expert, sub Submission) (*SynthesizedResult, error) {
+	return nil, errors.New("debates don't use the collective call")
+}
+
+func debateInputs(ids ...string) []ExpertInput {
+	inputs := make([]ExpertInput, len(ids))
+	for i, id := range ids {
+		inputs[i] = ExpertInput{Expert: &expert.Expert{ID: id, Name: id}}
+	}
+	return inputs
+}
+
+func TestRunDebate_Converges(t *testing.T) {
+	backend := &debateBackend{respond: func(e *expert.Expert, round int) (ExpertVerdict, error) {
+		v := ExpertVerdict{Expert: e.ID, Verdict: VerdictPass, Confidence: 0.8}
+		if e.ID == "kent" {
+			v.Verdict = VerdictBlock
+			if round > 1 {
+				v.Verdict = VerdictComment // conceded after reading rob
+			}
+		}
+		return v, nil
+	}}
+	runner := &Runner{Backend: backend, Options: ReviewOptions{Rounds: 5}}
+
+	result := runner.Run(context.Background(), debateInputs("kent", "rob"), Submission{Content: "diff"})
+
+	if result.Debate == nil {
+		t.Fatal("expected a debate record")
+	}
+	if result.Debate.Rounds != 3 || !result.Debate.Converged {
+		t.Errorf("debate = %d rounds, converged %v; want 3 rounds, converged", result.Debate.Rounds, result.Debate.Converged)
+	}
+	if got := result.Debate.Positions[0].String(); got != "kent: block → comment → comment" {
+		t.Errorf("kent's position = %q", got)
+	}
+	if result.Debate.Positions[1].Changed() {
+		t.Error("rob's verdict never changed")
+	}
+	if result.Verdict != VerdictComment {
+		t.Errorf("verdict = %s, want the final round's comment", result.Verdict)
+	}
+
+	// Blind first, then the previous round's verdicts
+	subs := backend.seen["rob"]
+	if len(subs[0].Debate) != 0 {
+		t.Error("round one should be blind")
+	}
+	if len(subs[1].Debate) != 2 || subs[1].Debate[0].Verdict != VerdictBlock {
+		t.Errorf("round two should see round one's verdicts, got %+v", subs[1].Debate)
+	}
+}
+
+func TestRunDebate_StillSplit(t *testing.T) {
+	backend := &debateBackend{respond: func(e *expert.Expert, round int) (ExpertVerdict, error) {
+		verdicts := []Verdict{VerdictPass, VerdictBlock}
+		return ExpertVerdict{Expert: e.ID, Verdict: verdicts[round%2]}, nil
+	}}
+	runner := &Runner{Backend: backend, Options: ReviewOptions{Rounds: 2}}
+
+	result := runner.Run(context.Background(), debateInputs("kent", "rob"), Submission{Content: "diff"})
+
+	if result.Debate.Rounds != 2 || result.Debate.Converged {
+		t.Errorf("debate = %d rounds, converged %v; want 2 rounds, not converged", result.Debate.Rounds, result.Debate.Converged)
+	}
+	if got := result.Debate.Outcome(); got != "still split after 2 rounds" {
+		t.Errorf("Outcome() = %q", got)
+	}
+}
+
+func TestRunDebate_FailedRoundKeepsVerdict(t *testing.T) {
+	backend := &debateBackend{respond: func(e *expert.Expert, round int) (ExpertVerdict, error) {
+		if e.ID == "kent" && round > 1 {
+			return ExpertVerdict{}, errors.New("timeout")
+		}
+		if e.ID == "ghost" {
+			return ExpertVerdict{}, errors.New("unreachable")
+		}
+		return ExpertVerdict{Expert: e.ID, Verdict: VerdictBlock}, nil
+	}}
+	runner := &Runner{Backend: backend, Options: ReviewOptions{Rounds: 2}}
+
+	result := runner.Run(context.Background(), debateInputs("kent", "rob", "ghost"), Submission{Content: "diff"})
+
+	if len(result.Perspectives) != 2 || result.Perspectives[0].Verdict != VerdictBlock {
+		t.Errorf("perspectives = %+v, want kent's round-one block kept", result.Perspectives)
+	}
+	if len(result.Errors) != 2 || !strings.Contains(result.Errors[0], "ghost") || !strings.Contains(result.Errors[1], "debate round 2: kent: timeout") {
+		t.Errorf("errors = %v, want ghost's blind-pass failure and kent's round-two failure", result.Errors)
+	}
+	if result.Debate.Converged {
+		t.Error("a round an expert failed to answer should not count as converged")
+	}
+	if len(backend.seen["ghost"]) != 1 {
+		t.Error("an expert that failed the blind pass should sit the debate out")
+	}
+	if len(result.Debate.Positions) != 2 || len(result.Debate.Positions[0].Verdicts) != 1 {
+		t.Errorf("positions = %+v", result.Debate.Positions)
+	}
+}
+
+func TestRunDebate_SingleRound(t *testing.T) {
+	backend := &MockBackend{CollectiveResult: &SynthesizedResult{Verdict: VerdictPass}}
+	runner := &Runner{Backend: backend, Options: ReviewOptions{Rounds: 1}}
+
+	result := runner.Run(context.Background(), debateInputs("kent", "rob"), Submission{Content: "diff"})
+
+	if result.Debate != nil {
+		t.Error("one round is a plain review, not a debate")
+	}
+	if backend.collectiveCalls.Load() != 1 {
+		t.Error("one round should keep the collective call")
+	}
+}
+
+func TestBuildPrompt_Debate(t *testing.T) {
+	e := &expert.Expert{ID: "kent", Name: "Kent"}
+	sub := Submission{Content: "diff", Debate: []ExpertVerdict{
+		{Expert: "kent", Verdict: VerdictBlock, Confidence: 0.9, Findings: []Finding{{File: "a.go", StartLine: 3, Message: "no tests"}}},
+		{Expert: "rob", Verdict: VerdictPass, Confidence: 0.7},
+	}}
+
+	prompt := BuildPrompt(e, sub)
+
+	for _, want := range []string{"## Previous Round", "### kent (you): block", "- a.go:3: no tests", "### rob: pass"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q", want)
+		}
+	}
+	if strings.Contains(BuildPrompt(e, Submission{Content: "diff"}), "Previous Round") {
+		t.Error("blind prompts should not mention a previous round")
+	}
+}
+
+func TestFormatHuman_Debate(t *testing.T) {
+	result := &SynthesizedResult{
+		Verdict: VerdictComment,
+		Debate: &Debate{Rounds: 2, Converged: true, Positions: []DebatePosition{
+			{Expert: "kent", Verdicts: []Verdict{VerdictBlock, VerdictComment}},
+		}},
+	}
+
+	out := FormatHuman(result, "", 1)
+
+	for _, want := range []string{"Debate: converged after 2 rounds.", "  kent: block → comment"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
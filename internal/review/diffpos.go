@@ -6,9 +6,11 @@ import (
 )
 
 // DiffPosition maps a file path and line number to the diff-relative position
-// required by the GitHub PR Reviews API.
+// required by the GitHub PR Reviews API, and to the hunk showing the line.
 type DiffPosition struct {
 	positions map[string]map[int]int // file -> line -> position
+	hunks     map[string]map[int]int // file -> line -> hunk index, for added and context lines
+	oldPaths  map[string]string      // renamed file -> path before the rename
 }
 
 // NewDiffPosition parses a unified diff and builds the position map.
@@ -18,6 +20,8 @@ type DiffPosition struct {
 func NewDiffPosition(diff string) *DiffPosition {
 	dp := &DiffPosition{
 		positions: make(map[string]map[int]int),
+		hunks:     make(map[string]map[int]int),
+		oldPaths:  make(map[string]string),
 	}
 
 	lines := strings.Split(diff, "\n")
@@ -25,6 +29,7 @@ func NewDiffPosition(diff string) *DiffPosition {
 	var position int  // 1-based position relative to first @@ in this file
 	var newLine int   // current line number in the new file
 	var inHunk bool   // whether we've seen at least one @@ for this file
+	var hunk int      // index of the current @@ across the whole diff
 
 	for _, line := range lines {
 		if strings.HasPrefix(line, "diff --git ") {
@@ -35,6 +40,11 @@ func NewDiffPosition(diff string) *DiffPosition {
 			continue
 		}
 
+		if strings.HasPrefix(line, "rename from ") && currentFile != "" {
+			dp.oldPaths[currentFile] = strings.TrimPrefix(line, "rename from ")
+			continue
+		}
+
 		// Skip diff metadata lines that aren't part of the hunk
 		if strings.HasPrefix(line, "index ") ||
 			strings.HasPrefix(line, "--- ") ||
@@ -56,6 +66,7 @@ func NewDiffPosition(diff string) *DiffPosition {
 				position++
 			}
 			inHunk = true
+			hunk++
 			newLine = parseHunkNewStart(line)
 			continue
 		}
@@ -64,6 +75,13 @@ func NewDiffPosition(diff string) *DiffPosition {
 			continue
 		}
 
+		if inHunk && (strings.HasPrefix(line, "+") || strings.HasPrefix(line, " ")) {
+			if dp.hunks[currentFile] == nil {
+				dp.hunks[currentFile] = make(map[int]int)
+			}
+			dp.hunks[currentFile][newLine] = hunk
+		}
+
 		if strings.HasPrefix(line, "+") {
 			position++
 			if dp.positions[currentFile] == nil {
@@ -95,6 +113,23 @@ func (dp *DiffPosition) Position(file string, line int) (int, bool) {
 	return pos, ok
 }
 
+// Hunk returns the index of the hunk that shows a line of the new version
+// of file, as an added or context line. Lines in the same hunk can anchor a
+// multi-line comment. Returns (0, false) when the line is not in the diff.
+func (dp *DiffPosition) Hunk(file string, line int) (int, bool) {
+	h, ok := dp.hunks[file][line]
+	return h, ok
+}
+
+// OldPath returns the path a file had before the diff, which differs from
+// file only for renames.
+func (dp *DiffPosition) OldPath(file string) string {
+	if old, ok := dp.oldPaths[file]; ok {
+		return old
+	}
+	return file
+}
+
 // Files returns the list of files present in the diff.
 func (dp *DiffPosition) Files() []string {
 	files := make([]string, 0, len(dp.positions))
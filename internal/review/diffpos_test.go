@@ -175,6 +175,62 @@ diff --git a/y.go b/y.go
 	}
 }
 
+func TestDiffPositionOldPath(t *testing.T) {
+	diff := `diff --git a/old/name.go b/new/name.go
+similarity index 90%
+rename from old/name.go
+rename to new/name.go
+--- a/old/name.go
++++ b/new/name.go
+@@ -1,2 +1,3 @@
+ package name
++// Renamed
+ func F() {}
+`
+
+	dp := NewDiffPosition(diff)
+
+	if got := dp.OldPath("new/name.go"); got != "old/name.go" {
+		t.Errorf("OldPath(renamed) = %q, want old/name.go", got)
+	}
+	if got := dp.OldPath("main.go"); got != "main.go" {
+		t.Errorf("OldPath(unchanged path) = %q, want main.go", got)
+	}
+	if _, ok := dp.Position("new/name.go", 2); !ok {
+		t.Error("expected the added line of a renamed file to be mapped")
+	}
+}
+
+func TestDiffPositionHunk(t *testing.T) {
+	diff := `diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,4 @@
+ package main
++var x = 1
+-var old = 0
+ func main() {}
+@@ -10,2 +11,2 @@
+ func other() {}
++var y = 2
+`
+
+	dp := NewDiffPosition(diff)
+
+	first, ok1 := dp.Hunk("main.go", 1)  // context
+	second, ok2 := dp.Hunk("main.go", 3) // context after a deletion
+	third, ok3 := dp.Hunk("main.go", 12) // added, second hunk
+	if !ok1 || !ok2 || !ok3 {
+		t.Fatalf("expected added and context lines to be in the diff: %v %v %v", ok1, ok2, ok3)
+	}
+	if first != second || first == third {
+		t.Errorf("hunks = %d, %d, %d; want the first two equal and the third different", first, second, third)
+	}
+	if _, ok := dp.Hunk("main.go", 5); ok {
+		t.Error("line between hunks should not be in the diff")
+	}
+}
+
 func TestDiffPositionEmptyDiff(t *testing.T) {
 	dp := NewDiffPosition("")
 	_, ok := dp.Position("anything.go", 1)
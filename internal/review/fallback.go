@@ -0,0 +1,117 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/luuuc/council/internal/expert"
+)
+
+// FallbackBackend tries a chain of backends in order, moving to the next
+// when one fails in a way another provider may not: rate limits and
+// outages that outlasted the backend's own retries, rejected credentials,
+// unreachable hosts, failed CLI runs, and with Timeout set, calls that ran
+// out of their own deadline. Requests the provider rejected as malformed,
+// calls the caller ran out of time or cancelled and calls over budget are
+// not retried elsewhere. Verdicts record the backend that served them in
+// Model, and Fallback when it wasn't the first.
+type FallbackBackend struct {
+	Backends []Backend     // the primary backend first
+	Timeout  time.Duration // optional: each backend's own deadline; zero shares the caller's across the chain
+}
+
+// NewFallbackBackend chains backends, the primary first.
+func NewFallbackBackend(backends ...Backend) *FallbackBackend {
+	return &FallbackBackend{Backends: backends}
+}
+
+// Name lists the chain, e.g. "api:anthropic/claude-sonnet-4-6 → api:openai/gpt-4o".
+func (f *FallbackBackend) Name() string {
+	names := make([]string, len(f.Backends))
+	for i, b := range f.Backends {
+		names[i] = BackendName(b)
+	}
+	return strings.Join(names, " → ")
+}
+
+// Review runs a single expert review on the first backend that succeeds.
+func (f *FallbackBackend) Review(ctx context.Context, e *expert.Expert, sub Submission) (ExpertVerdict, error) {
+	var err error
+	for i, b := range f.Backends {
+		if i > 0 {
+			reportProgress(ctx, ProgressEvent{Kind: ProgressFallback, Expert: e.ID, Backend: BackendName(b), Err: err})
+		}
+
+		var verdict ExpertVerdict
+		callCtx, cancel := f.callContext(ctx)
+		verdict, err = b.Review(callCtx, e, sub)
+		timedOut := f.timedOut(ctx, callCtx)
+		cancel()
+		if err == nil {
+			verdict.Model = BackendName(b)
+			verdict.Fallback = i > 0
+			return verdict, nil
+		}
+		if !timedOut && !failoverHelps(ctx, err) {
+			break
+		}
+	}
+	return ExpertVerdict{}, err
+}
+
+// ReviewCollective runs a collective review on the first backend that succeeds.
+func (f *FallbackBackend) ReviewCollective(ctx context.Context, experts []*expert.Expert, sub Submission) (*SynthesizedResult, error) {
+	var err error
+	for i, b := range f.Backends {
+		if i > 0 {
+			reportProgress(ctx, ProgressEvent{Kind: ProgressFallback, Expert: CollectiveLabel, Backend: BackendName(b), Err: err})
+		}
+
+		var result *SynthesizedResult
+		callCtx, cancel := f.callContext(ctx)
+		result, err = b.ReviewCollective(callCtx, experts, sub)
+		timedOut := f.timedOut(ctx, callCtx)
+		cancel()
+		if err == nil {
+			for j := range result.Perspectives {
+				result.Perspectives[j].Model = BackendName(b)
+				result.Perspectives[j].Fallback = i > 0
+			}
+			return result, nil
+		}
+		if !timedOut && !failoverHelps(ctx, err) {
+			break
+		}
+	}
+	return nil, err
+}
+
+// callContext returns the context for one backend's call: ctx, bounded by
+// Timeout when set.
+func (f *FallbackBackend) callContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if f.Timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, f.Timeout)
+}
+
+// timedOut reports whether a call ran out of its own deadline while the
+// caller still had time, which the next backend may make good on.
+func (f *FallbackBackend) timedOut(ctx, callCtx context.Context) bool {
+	return f.Timeout > 0 && ctx.Err() == nil && errors.Is(callCtx.Err(), context.DeadlineExceeded)
+}
+
+// failoverHelps reports whether the next backend of a chain may succeed
+// where err failed.
+func failoverHelps(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) || errors.Is(err, ErrBudgetExceeded) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Kind != APIErrBadRequest
+	}
+	return true
+}
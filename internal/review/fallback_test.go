@@ -0,0 +1,137 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/luuuc/council/internal/expert"
+)
+
+func TestFallbackBackend_Review(t *testing.T) {
+	down := &namedBackend{name: "api:anthropic/claude-sonnet-4-6", MockBackend: MockBackend{
+		Errors: map[string]error{"kent": &APIError{Kind: APIErrOverloaded, StatusCode: 529, Label: "kent"}},
+	}}
+	up := &namedBackend{name: "api:openai/gpt-4o"}
+	chain := NewFallbackBackend(down, up)
+
+	var mu sync.Mutex
+	var events []ProgressEvent
+	ctx := withProgress(context.Background(), func(ev ProgressEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, ev)
+	})
+
+	v, err := chain.Review(ctx, &expert.Expert{ID: "kent"}, Submission{Content: "diff"})
+	if err != nil {
+		t.Fatalf("Review() error = %v", err)
+	}
+	if v.Model != "api:openai/gpt-4o" || !v.Fallback {
+		t.Errorf("verdict served by %q (fallback %v), want the openai fallback", v.Model, v.Fallback)
+	}
+	if len(events) != 1 || events[0].Kind != ProgressFallback || events[0].Backend != "api:openai/gpt-4o" {
+		t.Errorf("events = %+v, want one fallback event", events)
+	}
+
+	v, err = chain.Review(context.Background(), &expert.Expert{ID: "rob"}, Submission{Content: "diff"})
+	if err != nil || v.Model != "api:anthropic/claude-sonnet-4-6" || v.Fallback {
+		t.Errorf("Review() = %q (fallback %v), %v; want the primary", v.Model, v.Fallback, err)
+	}
+}
+
+func TestFallbackBackend_NoFailover(t *testing.T) {
+	tests := map[string]error{
+		"bad request": &APIError{Kind: APIErrBadRequest, StatusCode: 400},
+		"timeout":     context.DeadlineExceeded,
+	}
+	for name, primaryErr := range tests {
+		t.Run(name, func(t *testing.T) {
+			primary := &MockBackend{Errors: map[string]error{"kent": primaryErr}}
+			next := &MockBackend{}
+			_, err := NewFallbackBackend(primary, next).Review(context.Background(), &expert.Expert{ID: "kent"}, Submission{})
+
+			if !errors.Is(err, primaryErr) {
+				t.Errorf("Review() error = %v, want the primary's", err)
+			}
+			if next.calls.Load() != 0 {
+				t.Error("the fallback should not have been tried")
+			}
+		})
+	}
+}
+
+func TestFallbackBackend_PerBackendTimeout(t *testing.T) {
+	slow := &namedBackend{name: "api:anthropic/claude-sonnet-4-6", MockBackend: MockBackend{Delay: time.Second}}
+	fast := &namedBackend{name: "api:ollama/llama3"}
+	chain := NewFallbackBackend(slow, fast)
+	chain.Timeout = 20 * time.Millisecond
+
+	// The runner gives the chain room for every backend's deadline
+	runner := &Runner{Backend: chain, Options: ReviewOptions{Timeout: 1}}
+	result := runner.Run(context.Background(), debateInputs("kent"), Submission{Content: "diff"})
+
+	if len(result.Perspectives) != 1 || result.Perspectives[0].Model != "api:ollama/llama3" || !result.Perspectives[0].Fallback {
+		t.Errorf("result = %+v, want kent served by the ollama fallback", result)
+	}
+
+	// Out of the caller's own time, the chain stops
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	fast.calls.Store(0)
+	if _, err := chain.Review(ctx, &expert.Expert{ID: "kent"}, Submission{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Review() error = %v, want the caller's deadline", err)
+	}
+	if fast.calls.Load() != 0 {
+		t.Error("the fallback should not be tried once the caller is out of time")
+	}
+}
+
+func TestFallbackBackend_AllFail(t *testing.T) {
+	first := &MockBackend{Errors: map[string]error{"kent": errors.New("connection refused")}}
+	last := &MockBackend{Errors: map[string]error{"kent": &APIError{Kind: APIErrAuth, StatusCode: 401}}}
+
+	_, err := NewFallbackBackend(first, last).Review(context.Background(), &expert.Expert{ID: "kent"}, Submission{})
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Kind != APIErrAuth {
+		t.Errorf("Review() error = %v, want the last backend's", err)
+	}
+}
+
+func TestFallbackBackend_Collective(t *testing.T) {
+	down := &namedBackend{name: "cli:claude", MockBackend: MockBackend{CollectiveErr: errors.New("exit status 1")}}
+	up := &namedBackend{name: "api:ollama/llama3", MockBackend: MockBackend{CollectiveResult: &SynthesizedResult{
+		Verdict:      VerdictComment,
+		Perspectives: []ExpertVerdict{{Expert: "kent", Verdict: VerdictComment}, {Expert: "rob", Verdict: VerdictPass}},
+	}}}
+	runner := &Runner{Backend: NewFallbackBackend(down, up)}
+
+	result := runner.Run(context.Background(), debateInputs("kent", "rob"), Submission{Content: "diff"})
+
+	for _, p := range result.Perspectives {
+		if p.Model != "api:ollama/llama3" || !p.Fallback {
+			t.Errorf("%s served by %q (fallback %v), want the ollama fallback", p.Expert, p.Model, p.Fallback)
+		}
+	}
+	if down.calls.Load() != 0 {
+		t.Error("the collective call succeeded on the fallback; no per-expert retry expected")
+	}
+}
+
+func TestFallbackBackend_NameAndModel(t *testing.T) {
+	api, err := NewAPIBackend("anthropic", "claude-sonnet-4-6")
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain := NewFallbackBackend(api, NewCLIBackend("claude", nil))
+
+	if got := BackendName(chain); got != "api:anthropic/claude-sonnet-4-6 → cli:claude -p --output-format text" {
+		t.Errorf("BackendName() = %q", got)
+	}
+	if got := BackendModel(chain); got != "claude-sonnet-4-6" {
+		t.Errorf("BackendModel() = %q, want the primary's model", got)
+	}
+}
@@ -0,0 +1,171 @@
+package review
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FindingSeverity grades a single finding, independent of the expert's
+// overall verdict.
+type FindingSeverity string
+
+const (
+	SeverityInfo    FindingSeverity = "info"    // worth knowing, no action needed
+	SeverityWarning FindingSeverity = "warning" // should be addressed
+	SeverityError   FindingSeverity = "error"   // must be fixed before shipping
+)
+
+// ValidSeverities is the set of recognized finding severities.
+var ValidSeverities = map[FindingSeverity]bool{
+	SeverityInfo:    true,
+	SeverityWarning: true,
+	SeverityError:   true,
+}
+
+// Finding is one concrete observation from an expert, optionally anchored to
+// a line range in the new version of a file.
+type Finding struct {
+	File       string          `json:"file,omitempty"`
+	StartLine  int             `json:"start_line,omitempty"`
+	EndLine    int             `json:"end_line,omitempty"`
+	Severity   FindingSeverity `json:"severity,omitempty"`
+	Category   string          `json:"category,omitempty"` // e.g. "security", "testing", "design"
+	Message    string          `json:"message"`
+	Suggestion string          `json:"suggestion,omitempty"` // replacement for lines StartLine..EndLine
+}
+
+// Location renders the file and line range, e.g. "main.go:10-12", or "" when
+// the finding is not anchored to a file.
+func (f Finding) Location() string {
+	switch {
+	case f.File == "":
+		return ""
+	case f.StartLine == 0:
+		return f.File
+	case f.EndLine > f.StartLine:
+		return fmt.Sprintf("%s:%d-%d", f.File, f.StartLine, f.EndLine)
+	default:
+		return fmt.Sprintf("%s:%d", f.File, f.StartLine)
+	}
+}
+
+// String renders the finding as a one-line note: "location: message".
+func (f Finding) String() string {
+	if loc := f.Location(); loc != "" {
+		return loc + ": " + f.Message
+	}
+	return f.Message
+}
+
+// AllFindings returns the expert's findings followed by its plain notes, the
+// fallback for models that don't produce findings. Notes starting with
+// "path:line" are anchored to that line.
+func (v ExpertVerdict) AllFindings() []Finding {
+	all := make([]Finding, 0, len(v.Findings)+len(v.Notes))
+	all = append(all, v.Findings...)
+	for _, note := range v.Notes {
+		all = append(all, noteFinding(note))
+	}
+	return all
+}
+
+// noteFinding converts a plain note into a Finding.
+func noteFinding(note string) Finding {
+	file, line, text := parseNoteFileRef(note)
+	return Finding{File: file, StartLine: line, EndLine: line, Message: text}
+}
+
+// SeverityFor returns the finding severity implied by a verdict, for
+// findings that don't carry their own.
+func SeverityFor(v Verdict) FindingSeverity {
+	switch v {
+	case VerdictBlock, VerdictEscalate:
+		return SeverityError
+	case VerdictComment:
+		return SeverityWarning
+	default:
+		return SeverityInfo
+	}
+}
+
+// effectiveSeverity returns the finding's own severity, or the one implied
+// by the verdict of the expert who raised it.
+func effectiveSeverity(f Finding, v Verdict) FindingSeverity {
+	if f.Severity != "" {
+		return f.Severity
+	}
+	return SeverityFor(v)
+}
+
+// normalizeFindings converts decoded JSON findings to []Finding. It is
+// lenient: line numbers may be numbers or numeric strings, "line" is
+// accepted for single-line findings, and plain strings are treated as notes.
+func normalizeFindings(v interface{}) []Finding {
+	items, ok := v.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	findings := make([]Finding, 0, len(items))
+	for _, item := range items {
+		switch it := item.(type) {
+		case string:
+			if it != "" {
+				findings = append(findings, noteFinding(it))
+			}
+		case map[string]interface{}:
+			f := Finding{
+				File:       stringField(it, "file", "path"),
+				StartLine:  intField(it, "start_line", "line"),
+				EndLine:    intField(it, "end_line"),
+				Severity:   FindingSeverity(strings.ToLower(stringField(it, "severity"))),
+				Category:   stringField(it, "category"),
+				Message:    stringField(it, "message", "note"),
+				Suggestion: stringField(it, "suggestion"),
+			}
+			if f.Message == "" {
+				continue
+			}
+			if !ValidSeverities[f.Severity] {
+				f.Severity = ""
+			}
+			if f.StartLine <= 0 {
+				f.StartLine = f.EndLine
+			}
+			if f.EndLine < f.StartLine {
+				f.EndLine = f.StartLine
+			}
+			findings = append(findings, f)
+		}
+	}
+	return findings
+}
+
+// stringField returns the first non-empty string value among keys.
+func stringField(m map[string]interface{}, keys ...string) string {
+	for _, k := range keys {
+		if s, ok := m[k].(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// intField returns the first positive integer value among keys, accepting
+// JSON numbers and numeric strings.
+func intField(m map[string]interface{}, keys ...string) int {
+	for _, k := range keys {
+		switch n := m[k].(type) {
+		case float64:
+			if n > 0 {
+				return int(n)
+			}
+		case string:
+			if i, err := strconv.Atoi(strings.TrimSpace(n)); err == nil && i > 0 {
+				return i
+			}
+		}
+	}
+	return 0
+}
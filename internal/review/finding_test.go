@@ -0,0 +1,70 @@
+package review
+
+import "testing"
+
+func TestFindingLocation(t *testing.T) {
+	tests := []struct {
+		f    Finding
+		want string
+	}{
+		{Finding{Message: "general"}, "general"},
+		{Finding{File: "main.go", Message: "m"}, "main.go: m"},
+		{Finding{File: "main.go", StartLine: 3, EndLine: 3, Message: "m"}, "main.go:3: m"},
+		{Finding{File: "main.go", StartLine: 3, EndLine: 7, Message: "m"}, "main.go:3-7: m"},
+	}
+	for _, tt := range tests {
+		if got := tt.f.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestAllFindingsIncludesNotes(t *testing.T) {
+	v := ExpertVerdict{
+		Findings: []Finding{{File: "a.go", StartLine: 1, EndLine: 2, Severity: SeverityError, Message: "structured"}},
+		Notes:    []string{"b.go:9: from a note", "general remark"},
+	}
+
+	all := v.AllFindings()
+	if len(all) != 3 {
+		t.Fatalf("AllFindings() returned %d findings, want 3", len(all))
+	}
+	if all[1].File != "b.go" || all[1].StartLine != 9 || all[1].EndLine != 9 || all[1].Message != "from a note" {
+		t.Errorf("note with file ref = %+v", all[1])
+	}
+	if all[2].File != "" || all[2].Message != "general remark" {
+		t.Errorf("plain note = %+v", all[2])
+	}
+}
+
+func TestNormalizeFindings(t *testing.T) {
+	raw := []interface{}{
+		map[string]interface{}{"file": "a.go", "start_line": float64(4), "end_line": float64(6), "severity": "ERROR", "category": "security", "message": "SQL injection", "suggestion": "db.Query(q, id)"},
+		map[string]interface{}{"path": "b.go", "line": "12", "severity": "critical", "message": "single line"},
+		map[string]interface{}{"file": "c.go", "start_line": float64(9), "end_line": float64(2), "message": "inverted range"},
+		map[string]interface{}{"file": "d.go"},
+		"e.go:3: plain string",
+	}
+
+	got := normalizeFindings(raw)
+	if len(got) != 4 {
+		t.Fatalf("got %d findings, want 4: %+v", len(got), got)
+	}
+
+	if got[0].Severity != SeverityError || got[0].Category != "security" || got[0].StartLine != 4 || got[0].EndLine != 6 || got[0].Suggestion == "" {
+		t.Errorf("full finding = %+v", got[0])
+	}
+	if got[1].File != "b.go" || got[1].StartLine != 12 || got[1].EndLine != 12 || got[1].Severity != "" {
+		t.Errorf("lenient finding = %+v", got[1])
+	}
+	if got[2].StartLine != 9 || got[2].EndLine != 9 {
+		t.Errorf("inverted range = %d-%d, want 9-9", got[2].StartLine, got[2].EndLine)
+	}
+	if got[3].File != "e.go" || got[3].StartLine != 3 || got[3].Message != "plain string" {
+		t.Errorf("string finding = %+v", got[3])
+	}
+
+	if normalizeFindings("not a list") != nil {
+		t.Error("non-list findings should normalize to nil")
+	}
+}
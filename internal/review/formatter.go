@@ -18,34 +18,72 @@ func FormatHuman(result *SynthesizedResult, packName string, expertCount int) st
 	}
 	b.WriteString(strings.Repeat("═", 50) + "\n\n")
 
-	// Perspectives
+	// Perspectives, naming the model when the council used several
+	showModel := mixedModels(result.AllPerspectives())
 	for _, p := range result.Perspectives {
-		name := p.Expert
-		verdict := string(p.Verdict)
+		writePerspective(&b, p, showModel)
+	}
 
-		// Right-align verdict
-		padding := 50 - len(name) - len(verdict)
-		if padding < 2 {
-			padding = 2
+	// Carried forward from the previous review
+	if result.Since != "" {
+		b.WriteString(strings.Repeat("─", 50) + "\n")
+		fmt.Fprintf(&b, "Incremental review since %s.\n", result.ShortSince())
+		if len(result.Carried) > 0 {
+			b.WriteString("Carried forward on unchanged files:\n\n")
+			for _, p := range result.Carried {
+				writePerspective(&b, p, showModel)
+			}
+		} else {
+			b.WriteByte('\n')
 		}
-		fmt.Fprintf(&b, "%s%s%s\n", name, strings.Repeat(" ", padding), verdict)
+	}
 
-		if p.Error != "" {
-			fmt.Fprintf(&b, "  (error: %s)\n", p.Error)
+	// Errors
+	if len(result.Errors) > 0 {
+		b.WriteString(strings.Repeat("─", 50) + "\n")
+		for _, e := range result.Errors {
+			fmt.Fprintf(&b, "Error: %s\n", e)
 		}
+		b.WriteByte('\n')
+	}
 
-		for _, note := range p.Notes {
-			fmt.Fprintf(&b, "  - %s\n", wrapNote(note, 46))
+	// Routing
+	if len(result.Routing) > 0 {
+		b.WriteString(strings.Repeat("─", 50) + "\n")
+		b.WriteString("Routing:\n")
+		councils, files := groupRouting(result.Routing)
+		for _, c := range councils {
+			fmt.Fprintf(&b, "  %s: %s\n", c, strings.Join(files[c], ", "))
 		}
+		b.WriteByte('\n')
+	}
 
+	// Skipped files
+	if len(result.Skipped) > 0 {
+		b.WriteString(strings.Repeat("─", 50) + "\n")
+		fmt.Fprintf(&b, "Skipped %d files:\n", len(result.Skipped))
+		for _, f := range result.Skipped {
+			fmt.Fprintf(&b, "  - %s (%s)\n", f.Path, f.Reason)
+		}
 		b.WriteByte('\n')
 	}
 
-	// Errors
-	if len(result.Errors) > 0 {
+	// Filtered files
+	if len(result.Filtered) > 0 {
 		b.WriteString(strings.Repeat("─", 50) + "\n")
-		for _, e := range result.Errors {
-			fmt.Fprintf(&b, "Error: %s\n", e)
+		fmt.Fprintf(&b, "Filtered %d files:\n", len(result.Filtered))
+		for _, f := range result.Filtered {
+			fmt.Fprintf(&b, "  - %s (%s)\n", f.Path, f.Reason)
+		}
+		b.WriteByte('\n')
+	}
+
+	// Debate rounds
+	if result.Debate != nil {
+		b.WriteString(strings.Repeat("─", 50) + "\n")
+		fmt.Fprintf(&b, "Debate: %s.\n", result.Debate.Outcome())
+		for _, p := range result.Debate.Positions {
+			fmt.Fprintf(&b, "  %s\n", p)
 		}
 		b.WriteByte('\n')
 	}
@@ -64,13 +102,69 @@ func FormatHuman(result *SynthesizedResult, packName string, expertCount int) st
 		b.WriteByte('\n')
 	}
 
+	// The chair's conclusion
+	if result.Chaired && result.Summary != "" {
+		fmt.Fprintf(&b, "Chair: %s\n\n", result.Summary)
+	}
+
+	// Tokens and cost
+	if result.Usage != nil {
+		fmt.Fprintf(&b, "Usage: %s\n", result.Usage)
+	}
+
 	// Verdict line
 	verdictLabel := verdictDisplayLabel(result.Verdict, result.Blocking)
-	fmt.Fprintf(&b, "Verdict: %s\n", verdictLabel)
+	if result.Policy != "" {
+		fmt.Fprintf(&b, "Verdict: %s (policy: %s)\n", verdictLabel, result.Policy)
+	} else {
+		fmt.Fprintf(&b, "Verdict: %s\n", verdictLabel)
+	}
 
 	return b.String()
 }
 
+// writePerspective renders one expert's verdict and findings, and the model
+// behind them when showModel is set.
+func writePerspective(b *strings.Builder, p ExpertVerdict, showModel bool) {
+	name := p.Expert
+	if p.File != "" {
+		name += " · " + p.File
+	}
+	verdict := string(p.Verdict)
+
+	// Right-align verdict
+	padding := 50 - len(name) - len(verdict)
+	if padding < 2 {
+		padding = 2
+	}
+	fmt.Fprintf(b, "%s%s%s\n", name, strings.Repeat(" ", padding), verdict)
+	if p.Fallback {
+		fmt.Fprintf(b, "  (model: %s, fallback)\n", p.Model)
+	} else if showModel && p.Model != "" {
+		fmt.Fprintf(b, "  (model: %s)\n", p.Model)
+	}
+
+	if p.Usage != nil {
+		fmt.Fprintf(b, "  (usage: %s)\n", p.Usage)
+	}
+
+	if p.Error != "" {
+		fmt.Fprintf(b, "  (error: %s)\n", p.Error)
+	}
+
+	for _, f := range p.AllFindings() {
+		fmt.Fprintf(b, "  - %s\n", wrapNote(findingLabel(f)+f.String(), 46))
+		if f.Suggestion != "" {
+			b.WriteString("    suggested:\n")
+			for _, line := range strings.Split(strings.TrimRight(f.Suggestion, "\n"), "\n") {
+				fmt.Fprintf(b, "    | %s\n", line)
+			}
+		}
+	}
+
+	b.WriteByte('\n')
+}
+
 // FormatJSON marshals a SynthesizedResult as indented JSON.
 func FormatJSON(result *SynthesizedResult) ([]byte, error) {
 	return json.MarshalIndent(result, "", "  ")
@@ -95,6 +189,22 @@ func verdictDisplayLabel(v Verdict, blocking bool) string {
 	}
 }
 
+// findingLabel renders a finding's severity and category as a prefix,
+// e.g. "[error · security] ". Plain notes have neither and get no prefix.
+func findingLabel(f Finding) string {
+	var parts []string
+	if f.Severity != "" {
+		parts = append(parts, string(f.Severity))
+	}
+	if f.Category != "" {
+		parts = append(parts, f.Category)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "[" + strings.Join(parts, " · ") + "] "
+}
+
 // wrapNote wraps a note at the given width for indented display.
 func wrapNote(note string, width int) string {
 	if len(note) <= width {
@@ -117,3 +227,19 @@ func wrapNote(note string, width int) string {
 	}
 	return strings.Join(lines, "\n    ")
 }
+
+// mixedModels reports whether the perspectives came from more than one
+// backend or model.
+func mixedModels(perspectives []ExpertVerdict) bool {
+	first := ""
+	for _, p := range perspectives {
+		switch {
+		case p.Model == "":
+		case first == "":
+			first = p.Model
+		case p.Model != first:
+			return true
+		}
+	}
+	return false
+}
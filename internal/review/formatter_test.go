@@ -101,3 +101,81 @@ func TestFormatJSON(t *testing.T) {
 		t.Errorf("parsed verdict = %q, want %q", parsed.Verdict, VerdictComment)
 	}
 }
+
+func TestFormatHumanFiltered(t *testing.T) {
+	result := &SynthesizedResult{
+		Verdict:  VerdictPass,
+		Filtered: []SkippedFile{{Path: "go.sum", Reason: `matched review.ignore "go.sum"`}},
+	}
+
+	output := FormatHuman(result, "", 1)
+
+	for _, check := range []string{"Filtered 1 files:", `go.sum (matched review.ignore "go.sum")`} {
+		if !strings.Contains(output, check) {
+			t.Errorf("output missing %q\n\nFull output:\n%s", check, output)
+		}
+	}
+}
+
+func TestFormatHumanRouting(t *testing.T) {
+	result := &SynthesizedResult{
+		Verdict: VerdictPass,
+		Routing: []FileRoute{
+			{Path: "app/models/user.rb", Council: "rails"},
+			{Path: "services/api/main.go", Council: "go"},
+			{Path: "app/jobs/sync.rb", Council: "rails"},
+		},
+	}
+
+	output := FormatHuman(result, "", 2)
+
+	for _, check := range []string{"Routing:", "rails: app/models/user.rb, app/jobs/sync.rb", "go: services/api/main.go"} {
+		if !strings.Contains(output, check) {
+			t.Errorf("output missing %q\n\nFull output:\n%s", check, output)
+		}
+	}
+}
+
+func TestFormatHumanFindings(t *testing.T) {
+	result := &SynthesizedResult{
+		Verdict: VerdictBlock,
+		Perspectives: []ExpertVerdict{
+			{
+				Expert:  "kent-beck",
+				Verdict: VerdictBlock,
+				Findings: []Finding{{
+					File: "main.go", StartLine: 10, EndLine: 12,
+					Severity: SeverityError, Category: "testing",
+					Message: "Untested branch", Suggestion: "return err",
+				}},
+				Notes: []string{"Plain note"},
+			},
+		},
+	}
+
+	output := FormatHuman(result, "", 1)
+
+	for _, check := range []string{"[error · testing] main.go:10-12: Untested", "suggested:", "| return err", "  - Plain note"} {
+		if !strings.Contains(output, check) {
+			t.Errorf("output missing %q\n\nFull output:\n%s", check, output)
+		}
+	}
+}
+
+func TestFormatHumanPerFile(t *testing.T) {
+	result := &SynthesizedResult{
+		Verdict: VerdictComment,
+		Perspectives: []ExpertVerdict{
+			{Expert: "kent-beck", Verdict: VerdictComment, File: "main.go", Notes: []string{"Add a test"}},
+		},
+		Skipped: []SkippedFile{{Path: "go.sum", Reason: "file too large for per-file review"}},
+	}
+
+	output := FormatHuman(result, "", 1)
+
+	for _, check := range []string{"kent-beck · main.go", "Skipped 1 files:", "go.sum (file too large for per-file review)"} {
+		if !strings.Contains(output, check) {
+			t.Errorf("output missing %q\n\nFull output:\n%s", check, output)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package review
+
+import "fmt"
+
+// FailOn is the threshold at which a review should fail a build or a hook.
+type FailOn string
+
+const (
+	FailOnComment  FailOn = "comment"  // any comment, block or escalate
+	FailOnBlock    FailOn = "block"    // a block or escalate verdict
+	FailOnEscalate FailOn = "escalate" // an escalate verdict
+	FailOnBlocking FailOn = "blocking" // only when a blocking expert blocks
+)
+
+// ParseFailOn validates a --fail-on value.
+func ParseFailOn(s string) (FailOn, error) {
+	switch f := FailOn(s); f {
+	case FailOnComment, FailOnBlock, FailOnEscalate, FailOnBlocking:
+		return f, nil
+	default:
+		return "", fmt.Errorf("invalid fail-on value '%s': must be one of: comment, block, escalate, blocking", s)
+	}
+}
+
+// Reached reports whether result meets the threshold.
+func (f FailOn) Reached(result *SynthesizedResult) bool {
+	if f == FailOnBlocking {
+		return result.Blocking
+	}
+	return result.Verdict.Severity() >= Verdict(f).Severity()
+}
+
+// AllFailed reports whether the review errored without producing a usable
+// verdict: every expert, or the collective call, failed.
+func (r *SynthesizedResult) AllFailed() bool {
+	if len(r.Errors) == 0 {
+		return false
+	}
+	for _, p := range r.Perspectives {
+		if p.Error == "" {
+			return false
+		}
+	}
+	return true
+}
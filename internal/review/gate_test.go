@@ -0,0 +1,57 @@
+package review
+
+import "testing"
+
+func TestParseFailOn(t *testing.T) {
+	for _, v := range []string{"comment", "block", "escalate", "blocking"} {
+		if _, err := ParseFailOn(v); err != nil {
+			t.Errorf("ParseFailOn(%q) error = %v", v, err)
+		}
+	}
+	if _, err := ParseFailOn("pass"); err == nil {
+		t.Error("ParseFailOn(pass) should fail")
+	}
+}
+
+func TestFailOnReached(t *testing.T) {
+	tests := []struct {
+		failOn   FailOn
+		verdict  Verdict
+		blocking bool
+		want     bool
+	}{
+		{FailOnComment, VerdictPass, false, false},
+		{FailOnComment, VerdictComment, false, true},
+		{FailOnBlock, VerdictComment, false, false},
+		{FailOnBlock, VerdictBlock, false, true},
+		{FailOnBlock, VerdictEscalate, false, true},
+		{FailOnEscalate, VerdictBlock, false, false},
+		{FailOnEscalate, VerdictEscalate, false, true},
+		{FailOnBlocking, VerdictBlock, false, false},
+		{FailOnBlocking, VerdictBlock, true, true},
+	}
+	for _, tt := range tests {
+		result := &SynthesizedResult{Verdict: tt.verdict, Blocking: tt.blocking}
+		if got := tt.failOn.Reached(result); got != tt.want {
+			t.Errorf("%s.Reached(%s, blocking=%v) = %v, want %v", tt.failOn, tt.verdict, tt.blocking, got, tt.want)
+		}
+	}
+}
+
+func TestAllFailed(t *testing.T) {
+	tests := []struct {
+		name   string
+		result SynthesizedResult
+		want   bool
+	}{
+		{"clean", SynthesizedResult{Perspectives: []ExpertVerdict{{Expert: "a"}}}, false},
+		{"partial", SynthesizedResult{Perspectives: []ExpertVerdict{{Expert: "a"}}, Errors: []string{"b: timeout"}}, false},
+		{"none", SynthesizedResult{Errors: []string{"a: timeout", "b: timeout"}}, true},
+		{"unparsed", SynthesizedResult{Perspectives: []ExpertVerdict{{Expert: "a", Error: "bad json"}}, Errors: []string{"b: timeout"}}, true},
+	}
+	for _, tt := range tests {
+		if got := tt.result.AllFailed(); got != tt.want {
+			t.Errorf("%s: AllFailed() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
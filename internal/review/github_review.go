@@ -1,8 +1,13 @@
 package review
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
+	"slices"
 	"strings"
 )
 
@@ -22,11 +27,15 @@ type GitHubReview struct {
 	Comments []GitHubReviewComment `json:"comments,omitempty"`
 }
 
-// GitHubReviewComment is an inline comment in a PR review.
+// GitHubReviewComment is an inline comment in a PR review, anchored on lines
+// of the new version of a file. StartLine is set for multi-line comments.
 type GitHubReviewComment struct {
-	Path     string `json:"path"`
-	Position int    `json:"position"`
-	Body     string `json:"body"`
+	Path      string `json:"path"`
+	Line      int    `json:"line,omitempty"`
+	Side      string `json:"side,omitempty"`
+	StartLine int    `json:"start_line,omitempty"`
+	StartSide string `json:"start_side,omitempty"`
+	Body      string `json:"body"`
 }
 
 // GitHubCheckRun is the payload for a GitHub Check Run annotation.
@@ -58,6 +67,116 @@ type GitHubCheckAnnotation struct {
 type GitHubOutput struct {
 	Review   GitHubReview   `json:"review"`
 	CheckRun GitHubCheckRun `json:"check_run"`
+	Carried  []string       `json:"carried,omitempty"` // files whose earlier findings were carried forward, so their inline comments still stand
+}
+
+// GitHubReviewMarker is a hidden HTML comment in every review body council
+// posts. It lets a later run find and supersede its earlier reviews.
+const GitHubReviewMarker = "<!-- council-review -->"
+
+// githubReviewedPrefix starts the hidden comment that follows the marker
+// when the review covered a commit (see SynthesizedResult.Reviewed), so an
+// incremental run on the pull request can continue from it.
+const githubReviewedPrefix = "<!-- council-reviewed: "
+
+// GitHubReviewedCommit returns the commit a council review body records as
+// reviewed, or "" when it records none.
+func GitHubReviewedCommit(body string) string {
+	_, rest, ok := strings.Cut(body, githubReviewedPrefix)
+	if !ok {
+		return ""
+	}
+	sha, _, ok := strings.Cut(rest, " -->")
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(sha)
+}
+
+// githubFindingsPrefix starts the hidden comment that carries, compressed,
+// the anchored findings of a review that covered a commit, so an
+// incremental run on the pull request can carry forward the ones on files
+// it doesn't review again.
+const githubFindingsPrefix = "<!-- council-findings: "
+
+// maxGitHubFindings caps the encoded findings. A review with more leaves
+// them out, and the next incremental run reviews the whole pull request.
+const maxGitHubFindings = 16 << 10
+
+// encodeGitHubFindings packs the findings CarryForward can keep, those
+// anchored to a file, as base64 gzipped JSON, or returns "" when they don't
+// fit in maxGitHubFindings.
+func encodeGitHubFindings(result *SynthesizedResult) string {
+	kept := []ExpertVerdict{}
+	for _, p := range result.AllPerspectives() {
+		if p.Error != "" {
+			continue
+		}
+		var anchored []Finding
+		for _, f := range p.AllFindings() {
+			if f.File != "" {
+				anchored = append(anchored, f)
+			}
+		}
+		if len(anchored) == 0 {
+			continue
+		}
+		kept = append(kept, ExpertVerdict{
+			Expert:     p.Expert,
+			Verdict:    p.Verdict,
+			Confidence: p.Confidence,
+			Findings:   anchored,
+			Blocking:   p.Blocking,
+			File:       p.File,
+			Council:    p.Council,
+		})
+	}
+
+	data, err := json.Marshal(kept)
+	if err != nil {
+		return ""
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil || zw.Close() != nil {
+		return ""
+	}
+	encoded := base64.StdEncoding.EncodeToString(buf.Bytes())
+	if len(encoded) > maxGitHubFindings {
+		return ""
+	}
+	return encoded
+}
+
+// GitHubReviewedFindings returns the findings a council review body
+// carries as the perspectives of a result, and false when it carries none:
+// the review covered no commit, or had too many findings to embed.
+func GitHubReviewedFindings(body string) (*SynthesizedResult, bool) {
+	_, rest, ok := strings.Cut(body, githubFindingsPrefix)
+	if !ok {
+		return nil, false
+	}
+	encoded, _, ok := strings.Cut(rest, " -->")
+	if !ok {
+		return nil, false
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, false
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, false
+	}
+	raw, err := io.ReadAll(io.LimitReader(zr, 16<<20))
+	if err != nil {
+		return nil, false
+	}
+	var perspectives []ExpertVerdict
+	if err := json.Unmarshal(raw, &perspectives); err != nil {
+		return nil, false
+	}
+	return &SynthesizedResult{Perspectives: perspectives}, true
 }
 
 // MapVerdictToEvent converts a Council verdict to a GitHub review event.
@@ -90,7 +209,7 @@ func FormatGitHubReview(result *SynthesizedResult, packName string, expertCount
 	var fallbacks []string
 	var mappedComments []GitHubReviewComment
 	for _, c := range comments {
-		if c.Position == 0 {
+		if c.Line == 0 {
 			fallbacks = append(fallbacks, c.Body)
 		} else {
 			mappedComments = append(mappedComments, c)
@@ -109,6 +228,15 @@ func FormatGitHubReview(result *SynthesizedResult, packName string, expertCount
 	checkTitle := fmt.Sprintf("%d experts reviewed, verdict: %s", expertCount, result.Verdict)
 	checkSummary := body
 
+	var carried []string
+	for _, p := range result.Carried {
+		for _, f := range p.Findings {
+			if !slices.Contains(carried, f.File) {
+				carried = append(carried, f.File)
+			}
+		}
+	}
+
 	return GitHubOutput{
 		Review: GitHubReview{
 			Event:    event,
@@ -125,6 +253,33 @@ func FormatGitHubReview(result *SynthesizedResult, packName string, expertCount
 				Annotations: annotations,
 			},
 		},
+		Carried: carried,
+	}
+}
+
+// FormatGitHubError builds the payload posted when a review could not run:
+// a comment-only review quoting detail (typically council's stderr) and a
+// neutral check run.
+func FormatGitHubError(detail string) GitHubOutput {
+	body := GitHubReviewMarker + "\n## Council Review\n\n⚠️ Review incomplete — council encountered an error."
+	if detail = strings.TrimSpace(detail); detail != "" {
+		body += "\n\n```\n" + detail + "\n```"
+	}
+
+	return GitHubOutput{
+		Review: GitHubReview{
+			Event: GitHubComment,
+			Body:  body,
+		},
+		CheckRun: GitHubCheckRun{
+			Name:       "Council Review",
+			Status:     "completed",
+			Conclusion: "neutral",
+			Output: GitHubCheckOutput{
+				Title:   "Review incomplete",
+				Summary: "Council encountered an error during review.",
+			},
+		},
 	}
 }
 
@@ -136,6 +291,13 @@ func FormatGitHubJSON(output GitHubOutput) ([]byte, error) {
 func formatReviewBody(result *SynthesizedResult, packName string, expertCount int) string {
 	var b strings.Builder
 
+	b.WriteString(GitHubReviewMarker + "\n")
+	if result.Reviewed != "" {
+		b.WriteString(githubReviewedPrefix + result.Reviewed + " -->\n")
+		if findings := encodeGitHubFindings(result); findings != "" {
+			b.WriteString(githubFindingsPrefix + findings + " -->\n")
+		}
+	}
 	b.WriteString("## Council Review\n\n")
 
 	passCount := 0
@@ -168,6 +330,14 @@ func formatReviewBody(result *SynthesizedResult, packName string, expertCount in
 	}
 	b.WriteString(".\n\n")
 
+	if result.Since != "" {
+		fmt.Fprintf(&b, "Incremental review of the changes since `%s`", result.ShortSince())
+		if n := carriedFindings(result); n > 0 {
+			fmt.Fprintf(&b, "; %d earlier finding(s) on unchanged files carried forward", n)
+		}
+		b.WriteString(".\n\n")
+	}
+
 	if len(result.Agreements) > 0 {
 		b.WriteString("### Agreements\n")
 		for _, a := range result.Agreements {
@@ -181,16 +351,51 @@ func formatReviewBody(result *SynthesizedResult, packName string, expertCount in
 		fmt.Fprintf(&b, "%s\n\n", result.Tension)
 	}
 
+	if result.Debate != nil {
+		b.WriteString("### Debate\n")
+		fmt.Fprintf(&b, "The council %s.\n", result.Debate.Outcome())
+		for _, p := range result.Debate.Positions {
+			if p.Changed() {
+				fmt.Fprintf(&b, "- %s\n", p)
+			}
+		}
+		b.WriteByte('\n')
+	}
+
 	if len(result.Perspectives) > 0 {
 		b.WriteString("### Individual Perspectives\n\n")
-		b.WriteString("| Expert | Verdict | Key Concern |\n")
-		b.WriteString("|---|---|---|\n")
+		showUsage := slices.ContainsFunc(result.Perspectives, func(p ExpertVerdict) bool { return p.Usage != nil })
+		if showUsage {
+			b.WriteString("| Expert | Verdict | Key Concern | Usage |\n")
+			b.WriteString("|---|---|---|---|\n")
+		} else {
+			b.WriteString("| Expert | Verdict | Key Concern |\n")
+			b.WriteString("|---|---|---|\n")
+		}
+		showModel := mixedModels(result.Perspectives)
 		for _, p := range result.Perspectives {
 			concern := "—"
-			if len(p.Notes) > 0 {
-				concern = truncateString(p.Notes[0], 80)
+			if findings := p.AllFindings(); len(findings) > 0 {
+				concern = truncateString(findings[0].Message, 80)
+			}
+			name := p.Expert
+			if p.File != "" {
+				name += " (`" + p.File + "`)"
+			}
+			if p.Fallback {
+				name += " · " + p.Model + " (fallback)"
+			} else if showModel && p.Model != "" {
+				name += " · " + p.Model
+			}
+			if showUsage {
+				usage := "—"
+				if p.Usage != nil {
+					usage = p.Usage.String()
+				}
+				fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", name, p.Verdict, concern, usage)
+			} else {
+				fmt.Fprintf(&b, "| %s | %s | %s |\n", name, p.Verdict, concern)
 			}
-			fmt.Fprintf(&b, "| %s | %s | %s |\n", p.Expert, p.Verdict, concern)
 		}
 		b.WriteByte('\n')
 	}
@@ -203,6 +408,23 @@ func formatReviewBody(result *SynthesizedResult, packName string, expertCount in
 		b.WriteByte('\n')
 	}
 
+	if len(result.Routing) > 0 {
+		b.WriteString("### Routing\n")
+		councils, files := groupRouting(result.Routing)
+		for _, c := range councils {
+			fmt.Fprintf(&b, "- **%s**: `%s`\n", c, strings.Join(files[c], "`, `"))
+		}
+		b.WriteByte('\n')
+	}
+
+	if len(result.Skipped) > 0 {
+		b.WriteString("### Skipped Files\n")
+		for _, f := range result.Skipped {
+			fmt.Fprintf(&b, "- `%s`: %s\n", f.Path, f.Reason)
+		}
+		b.WriteByte('\n')
+	}
+
 	if result.Summary != "" {
 		fmt.Fprintf(&b, "%s\n\n", result.Summary)
 	}
@@ -211,7 +433,17 @@ func formatReviewBody(result *SynthesizedResult, packName string, expertCount in
 	if packName != "" {
 		packLabel = packName
 	}
-	fmt.Fprintf(&b, "<sub>Reviewed by [Council](https://github.com/luuuc/council) · pack: %s · %d experts</sub>", packLabel, expertCount)
+	extra := ""
+	if result.Policy != "" {
+		extra += " · policy: " + result.Policy
+	}
+	if result.Chaired {
+		extra += " · synthesized by the chair"
+	}
+	if result.Usage != nil {
+		extra += " · " + result.Usage.String()
+	}
+	fmt.Fprintf(&b, "<sub>Reviewed by [Council](https://github.com/luuuc/council) · pack: %s · %d experts%s</sub>", packLabel, expertCount, extra)
 
 	return b.String()
 }
@@ -220,54 +452,97 @@ func extractInlineComments(result *SynthesizedResult, dp *DiffPosition) ([]GitHu
 	var comments []GitHubReviewComment
 	var annotations []GitHubCheckAnnotation
 
-	for _, p := range result.Perspectives {
-		for _, note := range p.Notes {
-			file, line, text := parseNoteFileRef(note)
-			if file == "" {
-				continue
-			}
-
-			body := fmt.Sprintf("**%s** (%s):\n%s", p.Expert, p.Verdict, text)
+	// Findings several experts raised are posted once, naming them all
+	for _, pf := range publishFindings(result.AllPerspectives()) {
+		f := pf.Finding
+		if f.File == "" || f.StartLine == 0 {
+			continue
+		}
 
-			pos, ok := dp.Position(file, line)
-			if ok {
-				comments = append(comments, GitHubReviewComment{
-					Path:     file,
-					Position: pos,
-					Body:     body,
-				})
-			} else {
-				// Can't map to diff position — add as fallback (position=0)
-				comments = append(comments, GitHubReviewComment{
-					Path: file,
-					Body: fmt.Sprintf("**%s** (%s) on `%s:%d`:\n%s", p.Expert, p.Verdict, file, line, text),
-				})
+		// Anchor on the last line of the range, where GitHub shows
+		// comments, spanning back to the first line when both are in
+		// the same hunk
+		hunk, ok := dp.Hunk(f.File, f.EndLine)
+		if ok {
+			c := GitHubReviewComment{Path: f.File, Line: f.EndLine, Side: "RIGHT"}
+			if start, ok := dp.Hunk(f.File, f.StartLine); ok && start == hunk && f.StartLine < f.EndLine {
+				c.StartLine, c.StartSide = f.StartLine, "RIGHT"
 			}
+			// A suggestion replaces exactly the commented lines
+			covered := c.StartLine != 0 || f.StartLine == f.EndLine
+			c.Body = fmt.Sprintf("**%s** (%s):\n%s", pf.Label(), pf.Perspective.Verdict, findingBody(f, covered))
+			comments = append(comments, c)
+		} else {
+			// Can't map to the diff — add as fallback (line=0)
+			comments = append(comments, GitHubReviewComment{
+				Path: f.File,
+				Body: fmt.Sprintf("**%s** (%s) on `%s`:\n%s", pf.Label(), pf.Perspective.Verdict, f.Location(), findingBody(f, false)),
+			})
+		}
 
-			var level string
-			switch p.Verdict {
-			case VerdictBlock, VerdictEscalate:
-				level = "failure"
-			case VerdictComment:
-				level = "warning"
-			default:
-				level = "notice"
-			}
+		var level string
+		switch effectiveSeverity(f, pf.Perspective.Verdict) {
+		case SeverityError:
+			level = "failure"
+		case SeverityWarning:
+			level = "warning"
+		default:
+			level = "notice"
+		}
 
-			annotations = append(annotations, GitHubCheckAnnotation{
-				Path:            file,
-				StartLine:       line,
-				EndLine:         line,
-				AnnotationLevel: level,
-				Message:         text,
-				Title:           fmt.Sprintf("%s (%s)", p.Expert, p.Verdict),
-			})
+		title := fmt.Sprintf("%s (%s)", pf.Label(), pf.Perspective.Verdict)
+		if f.Category != "" {
+			title += " · " + f.Category
 		}
+		annotations = append(annotations, GitHubCheckAnnotation{
+			Path:            f.File,
+			StartLine:       f.StartLine,
+			EndLine:         f.EndLine,
+			AnnotationLevel: level,
+			Message:         f.Message,
+			Title:           title,
+		})
 	}
 
 	return comments, annotations
 }
 
+// findingBody renders a finding's message, category and suggested code as
+// markdown for a review comment. With suggest set, the code becomes a
+// GitHub suggestion block the author can apply in one click; it must only
+// be set when the comment covers exactly the finding's lines.
+func findingBody(f Finding, suggest bool) string {
+	text := f.Message
+	if f.Category != "" {
+		text = fmt.Sprintf("_%s_ · %s", f.Category, text)
+	}
+	if f.Suggestion != "" {
+		code := strings.TrimRight(f.Suggestion, "\n")
+		fence := codeFence(code)
+		if suggest {
+			text += "\n\n" + fence + "suggestion\n" + code + "\n" + fence
+		} else {
+			text += "\n\nSuggested change:\n" + fence + "\n" + code + "\n" + fence
+		}
+	}
+	return text
+}
+
+// codeFence returns a backtick fence longer than any run of backticks in
+// code, so the code can't close the block early.
+func codeFence(code string) string {
+	longest, run := 0, 0
+	for _, r := range code {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return strings.Repeat("`", max(3, longest+1))
+}
+
 // parseNoteFileRef extracts a file:line reference from the beginning of a note.
 // Expected formats: "path/to/file.go:42: message" or "path/to/file.go:42 message"
 // Returns ("", 0, note) if no file reference is found.
@@ -134,8 +134,8 @@ func TestFormatGitHubReviewWithInlineComments(t *testing.T) {
 	if c.Path != "main.go" {
 		t.Errorf("comment path = %q, want main.go", c.Path)
 	}
-	if c.Position != 3 {
-		t.Errorf("comment position = %d, want 3", c.Position)
+	if c.Line != 3 || c.Side != "RIGHT" || c.StartLine != 0 {
+		t.Errorf("comment lines = %d-%d side %q, want single line 3 RIGHT", c.StartLine, c.Line, c.Side)
 	}
 	if !strings.Contains(c.Body, "**ada**") {
 		t.Error("comment should include expert attribution")
@@ -156,6 +156,108 @@ func TestFormatGitHubReviewWithInlineComments(t *testing.T) {
 	}
 }
 
+func TestFormatGitHubReviewFindings(t *testing.T) {
+	diff := `diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,5 @@
+ package main
++import "fmt"
++func run() { fmt.Println("hello") }
+ func main() {}
+`
+	dp := NewDiffPosition(diff)
+
+	result := &SynthesizedResult{
+		Verdict: VerdictComment,
+		Perspectives: []ExpertVerdict{
+			{Expert: "ada", Verdict: VerdictComment, Findings: []Finding{{
+				File: "main.go", StartLine: 2, EndLine: 3,
+				Severity: SeverityError, Category: "design",
+				Message: "Inline the helper", Suggestion: "func run() {}",
+			}}},
+		},
+	}
+
+	output := FormatGitHubReview(result, "code", 1, dp)
+
+	if len(output.Review.Comments) != 1 {
+		t.Fatalf("expected 1 inline comment, got %d", len(output.Review.Comments))
+	}
+	c := output.Review.Comments[0]
+	if c.StartLine != 2 || c.Line != 3 || c.StartSide != "RIGHT" || c.Side != "RIGHT" {
+		t.Errorf("comment lines = %d-%d, want a multi-line comment on 2-3", c.StartLine, c.Line)
+	}
+	for _, want := range []string{"_design_", "Inline the helper", "```suggestion\nfunc run() {}\n```"} {
+		if !strings.Contains(c.Body, want) {
+			t.Errorf("comment body missing %q:\n%s", want, c.Body)
+		}
+	}
+
+	a := output.CheckRun.Output.Annotations[0]
+	if a.StartLine != 2 || a.EndLine != 3 {
+		t.Errorf("annotation lines = %d-%d, want 2-3", a.StartLine, a.EndLine)
+	}
+	if a.AnnotationLevel != "failure" {
+		t.Errorf("annotation level = %s, want failure (finding severity wins over verdict)", a.AnnotationLevel)
+	}
+}
+
+func TestFormatGitHubReviewSuggestions(t *testing.T) {
+	diff := `diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,4 @@
+ package main
++var x = 1
+ func main() {}
+ // end
+@@ -10,2 +11,3 @@
+ func other() {}
++var y = 2
+ // tail
+`
+	dp := NewDiffPosition(diff)
+
+	result := &SynthesizedResult{
+		Verdict: VerdictComment,
+		Perspectives: []ExpertVerdict{
+			{Expert: "ada", Verdict: VerdictComment, Findings: []Finding{
+				// Context line in the hunk: commentable with line/side
+				{File: "main.go", StartLine: 3, EndLine: 3, Message: "Rename", Suggestion: "func Main() {}"},
+				// Range spanning two hunks: single-line comment, no suggestion block
+				{File: "main.go", StartLine: 2, EndLine: 12, Message: "Merge these", Suggestion: "var x, y = 1, 2"},
+				// Suggestion containing a code fence
+				{File: "main.go", StartLine: 2, EndLine: 2, Message: "Doc it", Suggestion: "// ```go\nvar x = 1"},
+			}},
+		},
+	}
+
+	output := FormatGitHubReview(result, "code", 1, dp)
+
+	if len(output.Review.Comments) != 3 {
+		t.Fatalf("expected 3 inline comments, got %d", len(output.Review.Comments))
+	}
+
+	context := output.Review.Comments[0]
+	if context.Line != 3 || !strings.Contains(context.Body, "```suggestion\nfunc Main() {}\n```") {
+		t.Errorf("context line comment = %+v", context)
+	}
+
+	split := output.Review.Comments[1]
+	if split.Line != 12 || split.StartLine != 0 {
+		t.Errorf("cross-hunk range should anchor on its last line only, got %d-%d", split.StartLine, split.Line)
+	}
+	if strings.Contains(split.Body, "```suggestion") || !strings.Contains(split.Body, "Suggested change:") {
+		t.Errorf("cross-hunk suggestion must not be applicable:\n%s", split.Body)
+	}
+
+	fenced := output.Review.Comments[2]
+	if !strings.Contains(fenced.Body, "````suggestion\n// ```go\nvar x = 1\n````") {
+		t.Errorf("suggestion fence should outlast backticks in the code:\n%s", fenced.Body)
+	}
+}
+
 func TestFormatGitHubReviewFallbackComment(t *testing.T) {
 	diff := `diff --git a/main.go b/main.go
 --- a/main.go
@@ -274,3 +376,76 @@ func TestFormatGitHubReviewCheckRunTitle(t *testing.T) {
 		t.Errorf("check title = %q, should mention expert count", output.CheckRun.Output.Title)
 	}
 }
+
+func TestFormatGitHubReviewSkippedFiles(t *testing.T) {
+	result := &SynthesizedResult{
+		Verdict: VerdictPass,
+		Skipped: []SkippedFile{{Path: "vendor/big.go", Reason: "file too large for per-file review"}},
+	}
+
+	output := FormatGitHubReview(result, "", 1, nil)
+
+	if !strings.Contains(output.Review.Body, "### Skipped Files") {
+		t.Error("body should have a Skipped Files section")
+	}
+	if !strings.Contains(output.Review.Body, "`vendor/big.go`: file too large") {
+		t.Errorf("body should list the skipped file, got:\n%s", output.Review.Body)
+	}
+}
+
+func TestFormatGitHubReviewRecordsReviewedCommit(t *testing.T) {
+	result := &SynthesizedResult{Verdict: VerdictPass, Reviewed: "0123456789abcdef"}
+
+	body := FormatGitHubReview(result, "", 1, nil).Review.Body
+
+	if !strings.HasPrefix(body, GitHubReviewMarker+"\n") {
+		t.Errorf("body should start with the marker, got:\n%s", body)
+	}
+	if got := GitHubReviewedCommit(body); got != "0123456789abcdef" {
+		t.Errorf("GitHubReviewedCommit() = %q, want the reviewed commit", got)
+	}
+	if got := GitHubReviewedCommit(FormatGitHubReview(&SynthesizedResult{Verdict: VerdictPass}, "", 1, nil).Review.Body); got != "" {
+		t.Errorf("GitHubReviewedCommit() = %q for a review of no commit", got)
+	}
+}
+
+func TestFormatGitHubReviewEmbedsFindingsToCarry(t *testing.T) {
+	result := &SynthesizedResult{
+		Verdict:  VerdictBlock,
+		Reviewed: "0123456789abcdef",
+		Perspectives: []ExpertVerdict{{
+			Expert:   "Kent Beck",
+			Verdict:  VerdictBlock,
+			Notes:    []string{"unanchored"},
+			Findings: []Finding{{File: "new.go", StartLine: 2, EndLine: 2, Message: "untested"}},
+		}},
+		Carried: []ExpertVerdict{{
+			Expert:   "Rob Pike",
+			Verdict:  VerdictComment,
+			Findings: []Finding{{File: "old.go", StartLine: 9, EndLine: 9, Message: "too clever"}},
+		}},
+	}
+
+	out := FormatGitHubReview(result, "", 1, nil)
+
+	prev, ok := GitHubReviewedFindings(out.Review.Body)
+	if !ok {
+		t.Fatalf("expected embedded findings in:\n%s", out.Review.Body)
+	}
+	var files []string
+	for _, p := range prev.Perspectives {
+		for _, f := range p.Findings {
+			files = append(files, f.File)
+		}
+	}
+	if strings.Join(files, ",") != "new.go,old.go" {
+		t.Errorf("embedded findings on %v, want the anchored findings of every perspective, carried ones included", files)
+	}
+	if strings.Join(out.Carried, ",") != "old.go" {
+		t.Errorf("Carried = %v, want the files whose findings were carried forward", out.Carried)
+	}
+
+	if _, ok := GitHubReviewedFindings(FormatGitHubReview(&SynthesizedResult{Verdict: VerdictPass}, "", 1, nil).Review.Body); ok {
+		t.Error("a review of no commit should embed no findings")
+	}
+}
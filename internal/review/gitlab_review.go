@@ -0,0 +1,177 @@
+package review
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// GitLabSHAs are the merge request diff refs GitLab needs to anchor a
+// discussion on a line (see diff_refs on the merge request API).
+type GitLabSHAs struct {
+	Base  string // merge base of the target branch and the MR head
+	Start string // target branch head the diff was computed against
+	Head  string // MR source head
+}
+
+// complete reports whether all three SHAs are known; GitLab rejects a
+// positioned discussion with any of them empty.
+func (s GitLabSHAs) complete() bool {
+	return s.Base != "" && s.Start != "" && s.Head != ""
+}
+
+// GitLabPosition anchors a discussion on a line of the new version of a file.
+type GitLabPosition struct {
+	BaseSHA      string `json:"base_sha"`
+	StartSHA     string `json:"start_sha"`
+	HeadSHA      string `json:"head_sha"`
+	PositionType string `json:"position_type"`
+	OldPath      string `json:"old_path"`
+	NewPath      string `json:"new_path"`
+	NewLine      int    `json:"new_line"`
+}
+
+// GitLabDiscussion is the payload for the GitLab MR Discussions API
+// (POST /projects/:id/merge_requests/:iid/discussions).
+type GitLabDiscussion struct {
+	Body     string          `json:"body"`
+	Position *GitLabPosition `json:"position,omitempty"`
+}
+
+// CodeQualityIssue is one entry of a GitLab Code Quality report
+// (artifacts:reports:codequality).
+type CodeQualityIssue struct {
+	Description string              `json:"description"`
+	CheckName   string              `json:"check_name"`
+	Fingerprint string              `json:"fingerprint"`
+	Severity    string              `json:"severity"`
+	Location    CodeQualityLocation `json:"location"`
+}
+
+// CodeQualityLocation is the file and line range of a Code Quality issue.
+type CodeQualityLocation struct {
+	Path  string           `json:"path"`
+	Lines CodeQualityLines `json:"lines"`
+}
+
+// CodeQualityLines is a 1-based, inclusive line range.
+type CodeQualityLines struct {
+	Begin int `json:"begin"`
+	End   int `json:"end"`
+}
+
+// GitLabOutput bundles the MR summary note, the inline discussions and the
+// Code Quality report.
+type GitLabOutput struct {
+	Verdict     Verdict            `json:"verdict"`
+	Blocking    bool               `json:"blocking"`
+	Note        string             `json:"note"` // summary note body (POST .../merge_requests/:iid/notes)
+	Discussions []GitLabDiscussion `json:"discussions"`
+	CodeQuality []CodeQualityIssue `json:"code_quality"`
+}
+
+// FormatGitLabReview builds the GitLab merge request payloads from a
+// SynthesizedResult. Findings on lines added by the diff become positioned
+// discussions; the rest are appended to the summary note. Without a diff or
+// any of the base, start and head SHAs, every finding goes into the note.
+// The Code Quality report lists every finding anchored to a file either
+// way.
+func FormatGitLabReview(result *SynthesizedResult, packName string, expertCount int, dp *DiffPosition, shas GitLabSHAs) GitLabOutput {
+	note := formatReviewBody(result, packName, expertCount)
+
+	discussions := []GitLabDiscussion{}
+	issues := []CodeQualityIssue{}
+	var fallbacks []string
+
+	for _, pf := range publishFindings(result.AllPerspectives()) {
+		f := pf.Finding
+		if f.File == "" || f.StartLine == 0 {
+			continue
+		}
+
+		issues = append(issues, codeQualityIssue(pf))
+
+		text := findingBody(f, false)
+		inDiff := false
+		if dp != nil && shas.complete() {
+			_, inDiff = dp.Position(f.File, f.EndLine)
+		}
+		if !inDiff {
+			fallbacks = append(fallbacks, fmt.Sprintf("**%s** (%s) on `%s`:\n%s", pf.Label(), pf.Perspective.Verdict, f.Location(), text))
+			continue
+		}
+
+		discussions = append(discussions, GitLabDiscussion{
+			Body: fmt.Sprintf("**%s** (%s):\n%s", pf.Label(), pf.Perspective.Verdict, text),
+			Position: &GitLabPosition{
+				BaseSHA:      shas.Base,
+				StartSHA:     shas.Start,
+				HeadSHA:      shas.Head,
+				PositionType: "text",
+				OldPath:      dp.OldPath(f.File),
+				NewPath:      f.File,
+				NewLine:      f.EndLine,
+			},
+		})
+	}
+
+	if len(fallbacks) > 0 {
+		note += "\n\n### Additional Comments\n\n" + strings.Join(fallbacks, "\n\n")
+	}
+
+	return GitLabOutput{
+		Verdict:     result.Verdict,
+		Blocking:    result.Blocking,
+		Note:        note,
+		Discussions: discussions,
+		CodeQuality: issues,
+	}
+}
+
+// FormatGitLabJSON marshals the GitLab output as indented JSON.
+func FormatGitLabJSON(output GitLabOutput) ([]byte, error) {
+	return json.MarshalIndent(output, "", "  ")
+}
+
+// FormatCodeQualityJSON marshals a Code Quality report as indented JSON.
+func FormatCodeQualityJSON(issues []CodeQualityIssue) ([]byte, error) {
+	return json.MarshalIndent(issues, "", "  ")
+}
+
+// codeQualityIssue converts a finding to a Code Quality issue. The
+// fingerprint leaves out line numbers so GitLab can match the same issue
+// across pipelines after the code around it moves.
+func codeQualityIssue(pf publishedFinding) CodeQualityIssue {
+	p, f := pf.Perspective, pf.Finding
+	sum := sha256.Sum256([]byte(strings.Join([]string{p.Expert, f.File, f.Category, f.Message}, "\x00")))
+
+	description := f.Message
+	if f.Category != "" {
+		description = f.Category + ": " + description
+	}
+
+	return CodeQualityIssue{
+		Description: fmt.Sprintf("%s (%s)", description, pf.Label()),
+		CheckName:   "council/" + p.Expert,
+		Fingerprint: hex.EncodeToString(sum[:]),
+		Severity:    codeQualitySeverity(effectiveSeverity(f, p.Verdict)),
+		Location: CodeQualityLocation{
+			Path:  f.File,
+			Lines: CodeQualityLines{Begin: f.StartLine, End: f.EndLine},
+		},
+	}
+}
+
+// codeQualitySeverity maps a finding severity to a Code Quality severity.
+func codeQualitySeverity(s FindingSeverity) string {
+	switch s {
+	case SeverityError:
+		return "critical"
+	case SeverityWarning:
+		return "major"
+	default:
+		return "info"
+	}
+}
@@ -0,0 +1,127 @@
+package review
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestFormatGitLabReview(t *testing.T) {
+	diff := `diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,5 @@
+ package main
++import "fmt"
++func run() { fmt.Println("hello") }
+ func main() {}
+`
+	dp := NewDiffPosition(diff)
+	shas := GitLabSHAs{Base: "base", Start: "start", Head: "head"}
+
+	result := &SynthesizedResult{
+		Verdict: VerdictBlock,
+		Perspectives: []ExpertVerdict{
+			{Expert: "ada", Verdict: VerdictBlock, Findings: []Finding{{
+				File: "main.go", StartLine: 2, EndLine: 3,
+				Category: "design", Message: "Inline the helper",
+			}}},
+			{Expert: "kent", Verdict: VerdictComment, Notes: []string{
+				"main.go:1: package comment missing",
+				"Overall fine",
+			}},
+		},
+	}
+
+	output := FormatGitLabReview(result, "go", 2, dp, shas)
+
+	if len(output.Discussions) != 1 {
+		t.Fatalf("expected 1 positioned discussion, got %d", len(output.Discussions))
+	}
+	d := output.Discussions[0]
+	want := GitLabPosition{BaseSHA: "base", StartSHA: "start", HeadSHA: "head", PositionType: "text", OldPath: "main.go", NewPath: "main.go", NewLine: 3}
+	if d.Position == nil || *d.Position != want {
+		t.Errorf("position = %+v, want %+v", d.Position, want)
+	}
+	if !strings.Contains(d.Body, "Inline the helper") {
+		t.Errorf("discussion body missing message:\n%s", d.Body)
+	}
+
+	// Line 1 is a context line, so the note falls back to the summary
+	if !strings.Contains(output.Note, "### Additional Comments") || !strings.Contains(output.Note, "package comment missing") {
+		t.Errorf("note should carry the unmapped finding:\n%s", output.Note)
+	}
+
+	if len(output.CodeQuality) != 2 {
+		t.Fatalf("expected 2 code quality issues, got %d", len(output.CodeQuality))
+	}
+	cq := output.CodeQuality[0]
+	if cq.Severity != "critical" || cq.CheckName != "council/ada" || cq.Location.Lines.Begin != 2 || cq.Location.Lines.End != 3 {
+		t.Errorf("unexpected code quality issue: %+v", cq)
+	}
+	if output.CodeQuality[1].Severity != "major" {
+		t.Errorf("comment verdict should map to major, got %s", output.CodeQuality[1].Severity)
+	}
+	if len(cq.Fingerprint) != 64 || cq.Fingerprint == output.CodeQuality[1].Fingerprint {
+		t.Errorf("fingerprints should be distinct sha256 hashes: %q, %q", cq.Fingerprint, output.CodeQuality[1].Fingerprint)
+	}
+}
+
+func TestFormatGitLabReviewWithoutHead(t *testing.T) {
+	diff := "diff --git a/a.go b/a.go\n--- a/a.go\n+++ b/a.go\n@@ -0,0 +1 @@\n+package a\n"
+	result := &SynthesizedResult{
+		Verdict: VerdictComment,
+		Perspectives: []ExpertVerdict{
+			{Expert: "ada", Verdict: VerdictComment, Notes: []string{"a.go:1: name the package"}},
+		},
+	}
+
+	// Outside GitLab CI and without --base, only the head is known.
+	for name, shas := range map[string]GitLabSHAs{"none": {}, "head only": {Head: "head"}} {
+		t.Run(name, func(t *testing.T) {
+			output := FormatGitLabReview(result, "", 1, NewDiffPosition(diff), shas)
+
+			if len(output.Discussions) != 0 {
+				t.Errorf("discussions need base, start and head SHAs, got %d", len(output.Discussions))
+			}
+			if !strings.Contains(output.Note, "name the package") {
+				t.Errorf("note should carry the finding:\n%s", output.Note)
+			}
+		})
+	}
+}
+
+func TestCodeQualityFingerprintIgnoresLines(t *testing.T) {
+	p := ExpertVerdict{Expert: "ada", Verdict: VerdictComment}
+	a := codeQualityIssue(publishedFinding{Perspective: p, Finding: Finding{File: "a.go", StartLine: 1, EndLine: 1, Message: "x"}, Experts: []string{"ada"}})
+	b := codeQualityIssue(publishedFinding{Perspective: p, Finding: Finding{File: "a.go", StartLine: 9, EndLine: 9, Message: "x"}, Experts: []string{"ada"}})
+	if a.Fingerprint != b.Fingerprint {
+		t.Error("moving a finding should keep its fingerprint")
+	}
+}
+
+func TestFormatCodeQualityJSON(t *testing.T) {
+	output := FormatGitLabReview(&SynthesizedResult{Verdict: VerdictPass}, "", 0, nil, GitLabSHAs{})
+
+	data, err := FormatCodeQualityJSON(output.CodeQuality)
+	if err != nil {
+		t.Fatalf("FormatCodeQualityJSON: %v", err)
+	}
+	if strings.TrimSpace(string(data)) != "[]" {
+		t.Errorf("empty report should be [], got %s", data)
+	}
+
+	data, err = FormatGitLabJSON(output)
+	if err != nil {
+		t.Fatalf("FormatGitLabJSON: %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	for _, key := range []string{"verdict", "note", "discussions", "code_quality"} {
+		if _, ok := decoded[key]; !ok {
+			t.Errorf("missing key %q", key)
+		}
+	}
+}
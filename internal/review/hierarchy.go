@@ -1,100 +1,159 @@
 package review
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/luuuc/council/internal/config"
 	"github.com/luuuc/council/internal/expert"
 )
 
-// Domain represents a review domain in the decision hierarchy.
-// When experts disagree, higher-priority domains take precedence.
-// Security > Product > Scope > Convention > Performance > Quality
-type Domain int
+// Domain is a review domain in the decision hierarchy, named in lowercase,
+// e.g. "security". An expert whose focus fits several domains lands in the
+// one with the highest priority. The built-in order is
+// Security > Product > Scope > Convention > Performance > Quality;
+// config.yaml can replace it, adding domains of its own (see Hierarchy).
+// Domains don't weigh verdicts by themselves; the domain-veto policy uses
+// them to decide who may block (see Policy).
+type Domain string
 
 const (
-	DomainQuality     Domain = iota // Lowest priority
-	DomainPerformance
-	DomainConvention
-	DomainScope
-	DomainProduct
-	DomainSecurity // Highest priority
+	DomainSecurity    Domain = "security" // Highest priority
+	DomainProduct     Domain = "product"
+	DomainScope       Domain = "scope"
+	DomainConvention  Domain = "convention"
+	DomainPerformance Domain = "performance"
+	DomainQuality     Domain = "quality" // Lowest priority
 )
 
-// domainKeywords maps keywords found in expert Focus fields to domains.
-var domainKeywords = map[string]Domain{
-	// Security
-	"security":        DomainSecurity,
-	"cryptography":    DomainSecurity,
-	"authentication":  DomainSecurity,
-	"authorization":   DomainSecurity,
-	"vulnerability":   DomainSecurity,
-	"threat":          DomainSecurity,
-	"encryption":      DomainSecurity,
-	"secure":          DomainSecurity,
-	"infosec":         DomainSecurity,
-	"appsec":          DomainSecurity,
-	"penetration":     DomainSecurity,
-	"safety":          DomainSecurity,
-
-	// Product
-	"product":    DomainProduct,
-	"ux":         DomainProduct,
-	"user":       DomainProduct,
-	"design":     DomainProduct,
-	"usability":  DomainProduct,
-	"experience": DomainProduct,
-	"interface":  DomainProduct,
-	"accessible": DomainProduct,
-
-	// Scope
-	"scope":         DomainScope,
-	"simplicity":    DomainScope,
-	"minimalism":    DomainScope,
-	"yagni":         DomainScope,
-	"lean":          DomainScope,
-	"pragmatism":    DomainScope,
-	"less is more":  DomainScope,
-
-	// Convention
-	"convention":  DomainConvention,
-	"style":       DomainConvention,
-	"consistency": DomainConvention,
-	"idiomatic":   DomainConvention,
-	"standard":    DomainConvention,
-	"readability": DomainConvention,
-	"clarity":     DomainConvention,
-
-	// Performance
-	"performance":   DomainPerformance,
-	"optimization":  DomainPerformance,
-	"scalability":   DomainPerformance,
-	"efficiency":    DomainPerformance,
-	"latency":       DomainPerformance,
-	"throughput":    DomainPerformance,
-	"memory":        DomainPerformance,
-	"caching":       DomainPerformance,
-
-	// Quality
-	"testing":       DomainQuality,
-	"test-driven":   DomainQuality,
-	"tdd":           DomainQuality,
-	"quality":       DomainQuality,
-	"refactoring":   DomainQuality,
-	"clean code":    DomainQuality,
-	"maintainab":    DomainQuality,
-	"reliability":   DomainQuality,
-	"observability": DomainQuality,
+// String returns the domain's name.
+func (d Domain) String() string {
+	return string(d)
 }
 
-// ExpertDomain determines an expert's primary domain from their Focus field.
-// Returns DomainQuality as the default if no keywords match.
-func ExpertDomain(e *expert.Expert) Domain {
+// DomainDef is one level of a Hierarchy: a domain and the keywords that
+// put an expert in it when found in the expert's Focus.
+type DomainDef struct {
+	Name     Domain   `json:"name"`
+	Keywords []string `json:"keywords,omitempty"`
+}
+
+// builtinDomains is the default hierarchy, highest priority first.
+var builtinDomains = []DomainDef{
+	{DomainSecurity, []string{"security", "cryptography", "authentication", "authorization", "vulnerability", "threat", "encryption", "secure", "infosec", "appsec", "penetration", "safety"}},
+	{DomainProduct, []string{"product", "ux", "user", "design", "usability", "experience", "interface", "accessible"}},
+	{DomainScope, []string{"scope", "simplicity", "minimalism", "yagni", "lean", "pragmatism", "less is more"}},
+	{DomainConvention, []string{"convention", "style", "consistency", "idiomatic", "standard", "readability", "clarity"}},
+	{DomainPerformance, []string{"performance", "optimization", "scalability", "efficiency", "latency", "throughput", "memory", "caching"}},
+	{DomainQuality, []string{"testing", "test-driven", "tdd", "quality", "refactoring", "clean code", "maintainab", "reliability", "observability"}},
+}
+
+// Hierarchy is an ordered list of domains, highest priority first. The
+// last domain is where experts nothing else matches end up.
+type Hierarchy struct {
+	Domains []DomainDef `json:"domains"`
+}
+
+// DefaultHierarchy returns the built-in hierarchy.
+func DefaultHierarchy() *Hierarchy {
+	return &Hierarchy{Domains: builtinDomains}
+}
+
+// NewHierarchy builds the hierarchy config.yaml declares with domains:,
+// highest priority first, or the built-in one when it declares none. A
+// built-in domain listed without keywords keeps its built-in keywords.
+func NewHierarchy(domains []config.DomainConfig) (*Hierarchy, error) {
+	if len(domains) == 0 {
+		return DefaultHierarchy(), nil
+	}
+
+	h := &Hierarchy{}
+	seen := make(map[Domain]bool)
+	for _, dc := range domains {
+		name := Domain(strings.ToLower(strings.TrimSpace(dc.Name)))
+		if name == "" {
+			return nil, fmt.Errorf("domains: every domain needs a name")
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("domains: '%s' is listed twice", name)
+		}
+		seen[name] = true
+
+		def := DomainDef{Name: name, Keywords: dc.Keywords}
+		if len(def.Keywords) == 0 {
+			if builtin, ok := builtinDomain(name); ok {
+				def.Keywords = builtin.Keywords
+			}
+		}
+		h.Domains = append(h.Domains, def)
+	}
+	return h, nil
+}
+
+// builtinDomain looks up a built-in domain by name.
+func builtinDomain(name Domain) (DomainDef, bool) {
+	for _, d := range builtinDomains {
+		if d.Name == name {
+			return d, true
+		}
+	}
+	return DomainDef{}, false
+}
+
+// Rank returns a domain's priority, higher is more important. Domains not
+// in the hierarchy rank below all of them.
+func (h *Hierarchy) Rank(d Domain) int {
+	for i, def := range h.Domains {
+		if def.Name == d {
+			return len(h.Domains) - i
+		}
+	}
+	return 0
+}
+
+// DomainMatch is the domain an expert resolves to and why.
+type DomainMatch struct {
+	Domain Domain `json:"domain"`
+	Reason string `json:"reason"`
+}
+
+// Classify resolves an expert's domain: the domain: frontmatter field when
+// set, otherwise the highest-priority domain with a keyword in the
+// expert's Focus, otherwise the lowest domain of the hierarchy.
+func (h *Hierarchy) Classify(e *expert.Expert) DomainMatch {
+	if e.Domain != "" {
+		d := Domain(strings.ToLower(e.Domain))
+		if h.Rank(d) == 0 {
+			return DomainMatch{Domain: d, Reason: "domain: frontmatter (not in the hierarchy, lowest priority)"}
+		}
+		return DomainMatch{Domain: d, Reason: "domain: frontmatter"}
+	}
+
 	focus := strings.ToLower(e.Focus)
-	best := DomainQuality
-	for keyword, domain := range domainKeywords {
-		if strings.Contains(focus, keyword) && domain > best {
-			best = domain
+	for _, def := range h.Domains {
+		for _, keyword := range def.Keywords {
+			if strings.Contains(focus, strings.ToLower(keyword)) {
+				return DomainMatch{Domain: def.Name, Reason: fmt.Sprintf("focus mentions %q", keyword)}
+			}
 		}
 	}
-	return best
+
+	if len(h.Domains) == 0 {
+		return DomainMatch{Domain: DomainQuality, Reason: "default"}
+	}
+	return DomainMatch{Domain: h.Domains[len(h.Domains)-1].Name, Reason: "no keyword in focus; lowest domain"}
+}
+
+// Tag records each expert's resolved domain in its Domain field, so the
+// rest of the review sees the domains of this hierarchy.
+func (h *Hierarchy) Tag(experts []*expert.Expert) {
+	for _, e := range experts {
+		e.Domain = string(h.Classify(e).Domain)
+	}
+}
+
+// ExpertDomain returns an expert's domain: its Domain field when set (see
+// Hierarchy.Tag), otherwise the built-in hierarchy's guess from Focus.
+func ExpertDomain(e *expert.Expert) Domain {
+	return DefaultHierarchy().Classify(e).Domain
 }
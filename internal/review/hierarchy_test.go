@@ -3,6 +3,7 @@ package review
 import (
 	"testing"
 
+	"github.com/luuuc/council/internal/config"
 	"github.com/luuuc/council/internal/expert"
 )
 
@@ -26,26 +27,109 @@ func TestExpertDomain(t *testing.T) {
 			e := &expert.Expert{Focus: tt.focus}
 			got := ExpertDomain(e)
 			if got != tt.want {
-				t.Errorf("ExpertDomain(%q) = %d, want %d", tt.focus, got, tt.want)
+				t.Errorf("ExpertDomain(%q) = %s, want %s", tt.focus, got, tt.want)
 			}
 		})
 	}
 }
 
 func TestDomainOrdering(t *testing.T) {
-	if DomainSecurity <= DomainProduct {
-		t.Error("Security should be higher priority than Product")
+	h := DefaultHierarchy()
+	order := []Domain{DomainSecurity, DomainProduct, DomainScope, DomainConvention, DomainPerformance, DomainQuality}
+	for i := 1; i < len(order); i++ {
+		if h.Rank(order[i-1]) <= h.Rank(order[i]) {
+			t.Errorf("%s should be higher priority than %s", order[i-1], order[i])
+		}
 	}
-	if DomainProduct <= DomainScope {
-		t.Error("Product should be higher priority than Scope")
+	if h.Rank("compliance") != 0 {
+		t.Error("unknown domains should rank below every domain")
 	}
-	if DomainScope <= DomainConvention {
-		t.Error("Scope should be higher priority than Convention")
+}
+
+func TestExpertDomain_Frontmatter(t *testing.T) {
+	e := &expert.Expert{Focus: "Application security", Domain: "Compliance"}
+	if got := ExpertDomain(e); got != "compliance" {
+		t.Errorf("ExpertDomain() = %s, want compliance", got)
+	}
+}
+
+func TestNewHierarchy(t *testing.T) {
+	h, err := NewHierarchy([]config.DomainConfig{
+		{Name: "security"},
+		{Name: "Compliance", Keywords: []string{"gdpr", "audit"}},
+		{Name: "accessibility", Keywords: []string{"a11y", "wcag"}},
+		{Name: "quality"},
+	})
+	if err != nil {
+		t.Fatalf("NewHierarchy() error = %v", err)
+	}
+
+	if h.Rank("compliance") <= h.Rank("accessibility") || h.Rank(DomainSecurity) <= h.Rank("compliance") {
+		t.Errorf("ranks don't follow the configured order: %+v", h.Domains)
+	}
+	if h.Rank(DomainProduct) != 0 {
+		t.Error("product is not in the configured hierarchy")
+	}
+
+	tests := []struct {
+		expert     expert.Expert
+		wantDomain Domain
+		wantReason string
+	}{
+		{expert.Expert{Focus: "GDPR and security audits"}, DomainSecurity, `focus mentions "security"`},
+		{expert.Expert{Focus: "GDPR data handling"}, "compliance", `focus mentions "gdpr"`},
+		{expert.Expert{Focus: "WCAG conformance"}, "accessibility", `focus mentions "wcag"`},
+		{expert.Expert{Focus: "Refactoring"}, DomainQuality, `focus mentions "refactoring"`},
+		{expert.Expert{Focus: "User experience design"}, DomainQuality, "no keyword in focus; lowest domain"},
+		{expert.Expert{Focus: "Application security", Domain: "accessibility"}, "accessibility", "domain: frontmatter"},
+		{expert.Expert{Focus: "Anything", Domain: "legal"}, "legal", "domain: frontmatter (not in the hierarchy, lowest priority)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expert.Focus, func(t *testing.T) {
+			got := h.Classify(&tt.expert)
+			if got.Domain != tt.wantDomain || got.Reason != tt.wantReason {
+				t.Errorf("Classify() = %s (%s), want %s (%s)", got.Domain, got.Reason, tt.wantDomain, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestNewHierarchy_Default(t *testing.T) {
+	h, err := NewHierarchy(nil)
+	if err != nil {
+		t.Fatalf("NewHierarchy() error = %v", err)
+	}
+	if len(h.Domains) != 6 || h.Domains[0].Name != DomainSecurity {
+		t.Errorf("NewHierarchy(nil) = %+v, want the built-in hierarchy", h.Domains)
+	}
+}
+
+func TestNewHierarchy_Invalid(t *testing.T) {
+	tests := map[string][]config.DomainConfig{
+		"empty name": {{Name: " "}},
+		"duplicate":  {{Name: "security"}, {Name: "Security"}},
 	}
-	if DomainConvention <= DomainPerformance {
-		t.Error("Convention should be higher priority than Performance")
+	for name, domains := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewHierarchy(domains); err == nil {
+				t.Error("NewHierarchy() expected an error")
+			}
+		})
+	}
+}
+
+func TestHierarchyTag(t *testing.T) {
+	h, err := NewHierarchy([]config.DomainConfig{{Name: "compliance", Keywords: []string{"soc2"}}, {Name: "quality"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	experts := []*expert.Expert{{ID: "auditor", Focus: "SOC2 controls"}, {ID: "tester", Focus: "TDD"}}
+	h.Tag(experts)
+
+	if experts[0].Domain != "compliance" || experts[1].Domain != "quality" {
+		t.Errorf("Tag() domains = %q, %q", experts[0].Domain, experts[1].Domain)
 	}
-	if DomainPerformance <= DomainQuality {
-		t.Error("Performance should be higher priority than Quality")
+	if got := ExpertDomain(experts[0]); got != "compliance" {
+		t.Errorf("ExpertDomain() after Tag = %s, want compliance", got)
 	}
 }
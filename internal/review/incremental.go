@@ -0,0 +1,100 @@
+package review
+
+import "github.com/luuuc/council/internal/expert"
+
+// CarryForward folds an earlier review into an incremental one that only
+// saw the changes since commit since. Findings the earlier review made on
+// files outside changed still stand, so they are kept in Carried, grouped by
+// the expert that raised them, and count towards blocking and towards the
+// verdict, which is resolved again under policy over every perspective.
+// Findings on changed files, and those not anchored to a file, are dropped:
+// the new review has looked at that code again.
+func (r *SynthesizedResult) CarryForward(prev *SynthesizedResult, since string, changed []string, policy Policy, experts map[string]*expert.Expert) {
+	r.Since = since
+
+	touched := make(map[string]bool, len(changed))
+	for _, path := range changed {
+		touched[path] = true
+	}
+
+	for _, p := range prev.AllPerspectives() {
+		if p.Error != "" {
+			continue
+		}
+		var kept []Finding
+		for _, f := range p.AllFindings() {
+			if f.File != "" && !touched[f.File] {
+				kept = append(kept, f)
+			}
+		}
+		if len(kept) == 0 {
+			continue
+		}
+		r.Carried = append(r.Carried, ExpertVerdict{
+			Expert:     p.Expert,
+			Verdict:    carriedVerdict(p.Verdict, kept),
+			Confidence: p.Confidence,
+			Notes:      []string{},
+			Findings:   kept,
+			Blocking:   p.Blocking,
+			File:       p.File,
+			Council:    p.Council,
+		})
+	}
+
+	if len(r.Carried) == 0 {
+		return
+	}
+
+	// A strict council never lowers the verdict it reached, which may be
+	// the chair's; other policies decide on every perspective
+	verdict := policy.Resolve(r.AllPerspectives(), experts)
+	if policy.strict() && r.Verdict.Severity() > verdict.Severity() {
+		verdict = r.Verdict
+	}
+	r.Verdict = verdict
+	r.Blocking = r.Blocking || ResolveBlocking(r.Carried)
+}
+
+// AllPerspectives returns the perspectives of this review followed by those
+// carried forward from an earlier one.
+func (r *SynthesizedResult) AllPerspectives() []ExpertVerdict {
+	if len(r.Carried) == 0 {
+		return r.Perspectives
+	}
+	all := make([]ExpertVerdict, 0, len(r.Perspectives)+len(r.Carried))
+	all = append(all, r.Perspectives...)
+	return append(all, r.Carried...)
+}
+
+// ShortSince returns the first 8 characters of Since.
+func (r *SynthesizedResult) ShortSince() string {
+	if len(r.Since) > 8 {
+		return r.Since[:8]
+	}
+	return r.Since
+}
+
+// carriedFindings counts the findings carried forward.
+func carriedFindings(r *SynthesizedResult) int {
+	n := 0
+	for _, c := range r.Carried {
+		n += len(c.Findings)
+	}
+	return n
+}
+
+// carriedVerdict caps a carried expert's verdict at comment unless one of
+// the findings it still stands by is an error: a block over code that has
+// since changed shouldn't keep blocking.
+func carriedVerdict(v Verdict, kept []Finding) Verdict {
+	if v.Severity() <= VerdictComment.Severity() {
+		return v
+	}
+	for _, f := range kept {
+		if effectiveSeverity(f, v) == SeverityError {
+			return v
+		}
+	}
+	return VerdictComment
+}
@@ -0,0 +1,126 @@
+package review
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCarryForward(t *testing.T) {
+	prev := &SynthesizedResult{
+		Verdict: VerdictBlock,
+		Perspectives: []ExpertVerdict{
+			{Expert: "security", Verdict: VerdictBlock, Blocking: true, Findings: []Finding{
+				{File: "auth.go", StartLine: 3, EndLine: 3, Severity: SeverityError, Message: "Token compared with =="},
+				{File: "main.go", StartLine: 8, EndLine: 8, Message: "Fixed since"},
+			}},
+			{Expert: "design", Verdict: VerdictBlock, Findings: []Finding{
+				{File: "util.go", StartLine: 1, EndLine: 1, Severity: SeverityInfo, Message: "Helper could be inlined"},
+			}},
+			{Expert: "tests", Verdict: VerdictComment, Notes: []string{"Add a regression test"}},
+			{Expert: "broken", Verdict: VerdictComment, Error: "timeout", Findings: []Finding{{File: "x.go", Message: "ignored"}}},
+		},
+	}
+
+	result := &SynthesizedResult{
+		Verdict:      VerdictPass,
+		Perspectives: []ExpertVerdict{{Expert: "security", Verdict: VerdictPass}},
+	}
+	result.CarryForward(prev, "0123456789abcdef", []string{"main.go"}, Policy{}, nil)
+
+	if result.Since != "0123456789abcdef" || result.ShortSince() != "01234567" {
+		t.Errorf("Since = %q, ShortSince = %q", result.Since, result.ShortSince())
+	}
+	if len(result.Carried) != 2 {
+		t.Fatalf("expected findings of 2 experts carried, got %+v", result.Carried)
+	}
+
+	sec := result.Carried[0]
+	if sec.Expert != "security" || len(sec.Findings) != 1 || sec.Findings[0].File != "auth.go" {
+		t.Errorf("only the finding on the unchanged file should be carried: %+v", sec)
+	}
+	if sec.Verdict != VerdictBlock {
+		t.Errorf("a block backed by an error finding should stand, got %s", sec.Verdict)
+	}
+	if design := result.Carried[1]; design.Verdict != VerdictComment {
+		t.Errorf("a block left with only info findings should drop to comment, got %s", design.Verdict)
+	}
+
+	if result.Verdict != VerdictBlock || !result.Blocking {
+		t.Errorf("carried findings should count: verdict %s, blocking %v", result.Verdict, result.Blocking)
+	}
+	if got := len(result.AllPerspectives()); got != 3 {
+		t.Errorf("AllPerspectives() has %d entries, want 3", got)
+	}
+}
+
+func TestCarryForwardNothingLeft(t *testing.T) {
+	prev := &SynthesizedResult{Perspectives: []ExpertVerdict{
+		{Expert: "security", Verdict: VerdictBlock, Blocking: true, Findings: []Finding{{File: "main.go", StartLine: 1, Message: "Fixed since"}}},
+	}}
+	result := &SynthesizedResult{Verdict: VerdictPass, Perspectives: []ExpertVerdict{{Expert: "security", Verdict: VerdictPass}}}
+	result.CarryForward(prev, "abc", []string{"main.go"}, Policy{}, nil)
+
+	if len(result.Carried) != 0 || result.Verdict != VerdictPass || result.Blocking {
+		t.Errorf("nothing should be carried when every file changed: %+v", result)
+	}
+}
+
+func TestCarryForwardUnderPolicy(t *testing.T) {
+	prev := &SynthesizedResult{Perspectives: []ExpertVerdict{
+		{Expert: "security", Verdict: VerdictBlock, Findings: []Finding{{File: "auth.go", StartLine: 3, Severity: SeverityError, Message: "Token compared with =="}}},
+	}}
+	result := &SynthesizedResult{Verdict: VerdictPass, Perspectives: []ExpertVerdict{
+		{Expert: "design", Verdict: VerdictPass},
+		{Expert: "tests", Verdict: VerdictPass},
+	}}
+	result.CarryForward(prev, "abc", []string{"main.go"}, Policy{Name: PolicyQuorum}, nil)
+
+	if len(result.Carried) != 1 || result.Carried[0].Verdict != VerdictBlock {
+		t.Fatalf("expected the security block carried, got %+v", result.Carried)
+	}
+	if result.Verdict != VerdictComment {
+		t.Errorf("one carried block is short of the quorum of 2: verdict %s, want comment", result.Verdict)
+	}
+}
+
+func TestCarryForwardKeepsChairVerdictWhenStrict(t *testing.T) {
+	for _, policy := range []Policy{{}, {Name: PolicyStrict}} {
+		prev := &SynthesizedResult{Perspectives: []ExpertVerdict{
+			{Expert: "design", Verdict: VerdictComment, Findings: []Finding{{File: "api.go", StartLine: 7, Message: "Rename the handler"}}},
+		}}
+		result := &SynthesizedResult{Verdict: VerdictBlock, Chaired: true, Perspectives: []ExpertVerdict{
+			{Expert: "tests", Verdict: VerdictComment},
+		}}
+		result.CarryForward(prev, "abc", []string{"main.go"}, policy, nil)
+
+		if result.Verdict != VerdictBlock {
+			t.Errorf("policy %q: verdict %s, a strict council should keep the chair's block", policy.Name, result.Verdict)
+		}
+	}
+}
+
+func TestFormatIncremental(t *testing.T) {
+	result := &SynthesizedResult{
+		Verdict:      VerdictComment,
+		Perspectives: []ExpertVerdict{{Expert: "security", Verdict: VerdictPass}},
+		Since:        "0123456789abcdef",
+		Carried: []ExpertVerdict{{Expert: "design", Verdict: VerdictComment, Findings: []Finding{
+			{File: "util.go", StartLine: 4, EndLine: 4, Message: "Helper could be inlined"},
+		}}},
+	}
+
+	human := FormatHuman(result, "go", 2)
+	for _, want := range []string{"Incremental review since 01234567.", "Carried forward on unchanged files:", "util.go:4: Helper could be inlined"} {
+		if !strings.Contains(human, want) {
+			t.Errorf("FormatHuman missing %q:\n%s", want, human)
+		}
+	}
+
+	out := FormatGitHubReview(result, "go", 2, NewDiffPosition(""))
+	if !strings.Contains(out.Review.Body, "changes since `01234567`; 1 earlier finding(s) on unchanged files carried forward") {
+		t.Errorf("review body should note the incremental review:\n%s", out.Review.Body)
+	}
+	if len(out.CheckRun.Output.Annotations) != 1 || out.CheckRun.Output.Annotations[0].Path != "util.go" {
+		t.Errorf("carried findings should keep their annotations: %+v", out.CheckRun.Output.Annotations)
+	}
+}
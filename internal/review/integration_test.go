@@ -164,8 +164,8 @@ func TestEndToEndPRReview(t *testing.T) {
 		if c.Path != "internal/handler/export.go" {
 			t.Errorf("comment path = %q, want internal/handler/export.go", c.Path)
 		}
-		if c.Position <= 0 {
-			t.Errorf("comment position should be positive, got %d", c.Position)
+		if c.Line != 24 || c.Side != "RIGHT" {
+			t.Errorf("comment line = %d side %q, want 24 RIGHT", c.Line, c.Side)
 		}
 		if !strings.Contains(c.Body, "**the-tdd-advocate**") {
 			t.Error("comment should attribute to the-tdd-advocate")
@@ -9,6 +9,10 @@ import (
 // jsonObjectRe matches a JSON object containing a "verdict" key.
 var jsonObjectRe = regexp.MustCompile(`\{[^{}]*"verdict"[^{}]*\}`)
 
+// verdictJSONStartRe matches the opening of a JSON object whose "verdict"
+// key comes before any nested object.
+var verdictJSONStartRe = regexp.MustCompile(`\{[^{}]*"verdict"`)
+
 // ParseVerdict extracts a structured ExpertVerdict from raw LLM output.
 // It tries multiple extraction strategies in order of specificity,
 // falling back to a low-confidence comment verdict if nothing works.
@@ -38,7 +42,16 @@ func ParseVerdict(expertID string, raw []byte) ExpertVerdict {
 		}
 	}
 
-	// Strategy 4: fallback
+	// Strategy 4: balanced extract, for objects with nested findings
+	if loc := verdictJSONStartRe.FindStringIndex(text); loc != nil {
+		if candidate := extractBalancedJSON(text[loc[0]:]); candidate != "" {
+			if v, ok := tryUnmarshal(expertID, []byte(candidate)); ok {
+				return v
+			}
+		}
+	}
+
+	// Strategy 5: fallback
 	return fallbackVerdict(expertID, truncate(text, 200))
 }
 
@@ -49,6 +62,7 @@ func tryUnmarshal(expertID string, data []byte) (ExpertVerdict, bool) {
 		Verdict    Verdict     `json:"verdict"`
 		Confidence float64     `json:"confidence"`
 		Notes      interface{} `json:"notes"`
+		Findings   interface{} `json:"findings"`
 		Blocking   bool        `json:"blocking"`
 	}
 
@@ -77,6 +91,7 @@ func tryUnmarshal(expertID string, data []byte) (ExpertVerdict, bool) {
 		Verdict:    raw.Verdict,
 		Confidence: raw.Confidence,
 		Notes:      notes,
+		Findings:   normalizeFindings(raw.Findings),
 		Blocking:   raw.Blocking,
 	}, true
 }
@@ -195,6 +210,7 @@ func tryUnmarshalCollective(text string, expectedExperts []string) (*Synthesized
 			Verdict    Verdict     `json:"verdict"`
 			Confidence float64     `json:"confidence"`
 			Notes      interface{} `json:"notes"`
+			Findings   interface{} `json:"findings"`
 			Blocking   bool        `json:"blocking"`
 		} `json:"perspectives"`
 		Agreements []string `json:"agreements"`
@@ -244,6 +260,7 @@ func tryUnmarshalCollective(text string, expectedExperts []string) (*Synthesized
 			Verdict:    verdict,
 			Confidence: conf,
 			Notes:      normalizeNotes(p.Notes),
+			Findings:   normalizeFindings(p.Findings),
 			Blocking:   p.Blocking,
 		})
 	}
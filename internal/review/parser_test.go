@@ -387,3 +387,49 @@ func mustJSON(v any) string {
 	}
 	return string(b)
 }
+
+func TestParseVerdictFindings(t *testing.T) {
+	raw := `Here is my review:
+{"expert":"kent-beck","verdict":"block","confidence":0.8,"findings":[{"file":"main.go","start_line":10,"end_line":12,"severity":"error","category":"testing","message":"No test covers this branch","suggestion":"if err != nil {\n\treturn err\n}"}],"blocking":true}
+Hope this helps.`
+
+	v := ParseVerdict("kent-beck", []byte(raw))
+
+	if v.Error != "" {
+		t.Fatalf("unexpected parse error: %s", v.Error)
+	}
+	if v.Verdict != VerdictBlock {
+		t.Errorf("verdict = %s, want block", v.Verdict)
+	}
+	if len(v.Findings) != 1 {
+		t.Fatalf("findings = %d, want 1", len(v.Findings))
+	}
+	f := v.Findings[0]
+	if f.File != "main.go" || f.StartLine != 10 || f.EndLine != 12 || f.Severity != SeverityError || f.Category != "testing" {
+		t.Errorf("finding = %+v", f)
+	}
+	if f.Suggestion != "if err != nil {\n\treturn err\n}" {
+		t.Errorf("suggestion = %q", f.Suggestion)
+	}
+}
+
+func TestParseCollectiveResultFindings(t *testing.T) {
+	raw := `{"verdict":"comment","blocking":false,"perspectives":[
+		{"expert":"kent-beck","verdict":"comment","confidence":0.7,"findings":[{"file":"a.go","line":3,"severity":"warning","message":"Add a test"}],"blocking":false},
+		{"expert":"bruce-schneier","verdict":"pass","confidence":0.9,"notes":["Looks safe"],"blocking":false}
+	],"agreements":[],"tension":"","summary":"Minor"}`
+
+	result := ParseCollectiveResult([]byte(raw), []string{"kent-beck", "bruce-schneier"})
+
+	if len(result.Perspectives) != 2 {
+		t.Fatalf("perspectives = %d, want 2", len(result.Perspectives))
+	}
+	kb := result.Perspectives[0]
+	if len(kb.Findings) != 1 || kb.Findings[0].File != "a.go" || kb.Findings[0].StartLine != 3 {
+		t.Errorf("kent-beck findings = %+v", kb.Findings)
+	}
+	bs := result.Perspectives[1]
+	if len(bs.Findings) != 0 || len(bs.Notes) != 1 {
+		t.Errorf("bruce-schneier should keep plain notes, got findings=%+v notes=%v", bs.Findings, bs.Notes)
+	}
+}
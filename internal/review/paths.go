@@ -0,0 +1,87 @@
+package review
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// PathRules decides which files of a diff are reviewed. Patterns follow
+// gitignore conventions: a pattern without a slash matches at any depth
+// ("go.sum", "*.snap"), a trailing slash matches a directory and everything
+// under it ("vendor/"), and "**" matches any number of directories
+// ("db/migrate/**", "**/testdata/*").
+type PathRules struct {
+	Ignore  []string // files matching any pattern are left out
+	Include []string // if non-empty, only files matching a pattern are kept
+}
+
+// Empty reports whether the rules keep every file.
+func (r PathRules) Empty() bool {
+	return len(r.Ignore) == 0 && len(r.Include) == 0
+}
+
+// Exclude returns why path should be left out of the review, or "" to
+// keep it. Its signature fits FilterDiff.
+func (r PathRules) Exclude(p string) string {
+	if len(r.Include) > 0 && firstMatch(r.Include, p) == "" {
+		return "not matched by review.include"
+	}
+	if pattern := firstMatch(r.Ignore, p); pattern != "" {
+		return fmt.Sprintf("matched review.ignore %q", pattern)
+	}
+	return ""
+}
+
+// firstMatch returns the first pattern matching p, or "".
+func firstMatch(patterns []string, p string) string {
+	for _, pattern := range patterns {
+		if MatchPath(pattern, p) {
+			return pattern
+		}
+	}
+	return ""
+}
+
+// MatchPath reports whether a slash-separated path matches a
+// gitignore-style pattern (see PathRules). Malformed patterns match nothing.
+func MatchPath(pattern, p string) bool {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return false
+	}
+
+	if strings.HasSuffix(pattern, "/") {
+		pattern += "**"
+	}
+	pattern = strings.TrimPrefix(pattern, "/")
+	if !strings.Contains(strings.TrimSuffix(pattern, "/**"), "/") {
+		pattern = "**/" + pattern
+	}
+
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(strings.TrimPrefix(p, "/"), "/"))
+}
+
+// matchSegments matches path segments against pattern segments, where a
+// "**" segment consumes zero or more path segments.
+func matchSegments(pattern, parts []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			rest := pattern[1:]
+			for i := 0; i <= len(parts); i++ {
+				if matchSegments(rest, parts[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(parts) == 0 {
+			return false
+		}
+		if ok, err := path.Match(pattern[0], parts[0]); err != nil || !ok {
+			return false
+		}
+		pattern, parts = pattern[1:], parts[1:]
+	}
+	return len(parts) == 0
+}
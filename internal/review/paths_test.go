@@ -0,0 +1,62 @@
+package review
+
+import "testing"
+
+func TestMatchPath(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"go.sum", "go.sum", true},
+		{"go.sum", "tools/go.sum", true},
+		{"go.sum", "go.summary", false},
+		{"*.snap", "src/__snapshots__/app.test.js.snap", true},
+		{"vendor/", "vendor/github.com/x/y.go", true},
+		{"vendor/", "third_party/vendor/lib.go", true},
+		{"vendor/", "vendored.go", false},
+		{"db/migrate/**", "db/migrate/20240101_add_users.rb", true},
+		{"db/migrate/**", "engines/db/migrate/x.rb", false},
+		{"/db/schema.rb", "db/schema.rb", true},
+		{"**/testdata/*", "internal/review/testdata/a.diff", true},
+		{"**/testdata/*", "internal/review/testdata/deep/a.diff", false},
+		{"app/**/*.rb", "app/models/user.rb", true},
+		{"app/**/*.rb", "app/user.rb", true},
+		{"app/**/*.rb", "lib/user.rb", false},
+		{"[", "x", false},
+		{"", "x", false},
+	}
+	for _, tt := range tests {
+		if got := MatchPath(tt.pattern, tt.path); got != tt.want {
+			t.Errorf("MatchPath(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestPathRulesExclude(t *testing.T) {
+	rules := PathRules{
+		Include: []string{"app/", "lib/"},
+		Ignore:  []string{"*.snap"},
+	}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"app/models/user.rb", ""},
+		{"app/__snapshots__/x.snap", `matched review.ignore "*.snap"`},
+		{"go.sum", "not matched by review.include"},
+	}
+	for _, tt := range tests {
+		if got := rules.Exclude(tt.path); got != tt.want {
+			t.Errorf("Exclude(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+
+	if !(PathRules{}).Empty() || rules.Empty() {
+		t.Error("Empty() should be true only without patterns")
+	}
+	if got := (PathRules{}).Exclude("anything.go"); got != "" {
+		t.Errorf("empty rules should keep every file, got %q", got)
+	}
+}
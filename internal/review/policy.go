@@ -0,0 +1,213 @@
+package review
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/luuuc/council/internal/expert"
+	"gopkg.in/yaml.v3"
+)
+
+// PolicyName identifies a verdict resolution policy.
+type PolicyName string
+
+const (
+	PolicyStrict     PolicyName = "strict"              // the most severe verdict wins
+	PolicyMajority   PolicyName = "majority"            // the verdict more than half the experts reach
+	PolicyConfidence PolicyName = "confidence-weighted" // majority weighted by each expert's confidence
+	PolicyDomainVeto PolicyName = "domain-veto"         // only experts of the veto domains can block
+	PolicyQuorum     PolicyName = "quorum"              // a block needs Quorum blocking verdicts
+)
+
+// DefaultVetoDomains are the domains allowed to block under domain-veto
+// when the policy names none.
+var DefaultVetoDomains = []string{DomainSecurity.String(), DomainProduct.String()}
+
+// DefaultQuorum is how many blocks the quorum policy needs when the policy
+// doesn't say.
+const DefaultQuorum = 2
+
+// Policy decides how a council's verdicts combine into the overall
+// verdict. It is declared per pack:
+//
+//	policy: majority
+//
+//	policy:
+//	  name: quorum
+//	  quorum: 3
+//
+// The zero Policy is strict. Policies only change the overall verdict;
+// blocking members block whatever the policy (see ResolveBlocking).
+type Policy struct {
+	Name   PolicyName `yaml:"name" json:"name"`
+	Quorum int        `yaml:"quorum,omitempty" json:"quorum,omitempty"` // quorum: blocks needed, DefaultQuorum when 0
+	Veto   []string   `yaml:"veto,omitempty" json:"veto,omitempty"`     // domain-veto: domains that may block, DefaultVetoDomains when empty
+}
+
+// UnmarshalYAML accepts a bare policy name as well as the full mapping.
+func (p *Policy) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*p = Policy{Name: PolicyName(node.Value)}
+		return nil
+	}
+	type plain Policy
+	return node.Decode((*plain)(p))
+}
+
+// Validate checks the policy name and settings.
+func (p Policy) Validate() error {
+	switch p.Name {
+	case "", PolicyStrict, PolicyMajority, PolicyConfidence, PolicyDomainVeto, PolicyQuorum:
+	default:
+		return fmt.Errorf("unknown verdict policy '%s': must be one of: strict, majority, confidence-weighted, domain-veto, quorum", p.Name)
+	}
+	if p.Quorum < 0 {
+		return fmt.Errorf("quorum must be positive, got %d", p.Quorum)
+	}
+	return nil
+}
+
+// String describes the policy for output, e.g. "quorum (2)" or
+// "domain-veto (security, product)".
+func (p Policy) String() string {
+	switch p.Name {
+	case "":
+		return string(PolicyStrict)
+	case PolicyQuorum:
+		return fmt.Sprintf("%s (%d)", p.Name, p.quorum())
+	case PolicyDomainVeto:
+		return fmt.Sprintf("%s (%s)", p.Name, strings.Join(p.vetoDomains(), ", "))
+	default:
+		return string(p.Name)
+	}
+}
+
+// strict reports whether the policy is strict, declared or not.
+func (p Policy) strict() bool {
+	return p.Name == "" || p.Name == PolicyStrict
+}
+
+// quorum returns the blocks needed under the quorum policy.
+func (p Policy) quorum() int {
+	if p.Quorum > 0 {
+		return p.Quorum
+	}
+	return DefaultQuorum
+}
+
+// vetoDomains returns the domains that may block under domain-veto.
+func (p Policy) vetoDomains() []string {
+	if len(p.Veto) > 0 {
+		return p.Veto
+	}
+	return DefaultVetoDomains
+}
+
+// Resolve combines verdicts into the overall verdict under the policy.
+// Failed experts are left out.
+func (p Policy) Resolve(verdicts []ExpertVerdict, experts map[string]*expert.Expert) Verdict {
+	switch p.Name {
+	case PolicyMajority:
+		return weightedMajority(verdicts, func(ExpertVerdict) float64 { return 1 })
+	case PolicyConfidence:
+		return weightedMajority(verdicts, func(v ExpertVerdict) float64 { return v.Confidence })
+	case PolicyDomainVeto:
+		veto := make(map[string]bool)
+		for _, d := range p.vetoDomains() {
+			veto[strings.ToLower(d)] = true
+		}
+		capped := make([]ExpertVerdict, len(verdicts))
+		for i, v := range verdicts {
+			capped[i] = v
+			domain := DomainQuality
+			if e, ok := experts[v.Expert]; ok {
+				domain = ExpertDomain(e)
+			}
+			if !veto[domain.String()] {
+				capped[i].Verdict = capAtComment(v.Verdict)
+			}
+		}
+		return ResolveOverallVerdict(capped)
+	case PolicyQuorum:
+		blocks := 0
+		for _, v := range verdicts {
+			if v.Error == "" && v.Verdict.Severity() >= VerdictBlock.Severity() {
+				blocks++
+			}
+		}
+		overall := ResolveOverallVerdict(verdicts)
+		if blocks < p.quorum() {
+			overall = capAtComment(overall)
+		}
+		return overall
+	default:
+		return ResolveOverallVerdict(verdicts)
+	}
+}
+
+// Apply re-resolves result's verdict under the policy and records the
+// policy in the result. A strict policy nobody declared leaves no trace,
+// and a declared one never lowers the verdict it is given, which may be
+// the chair's. When the verdict moves, the summary says so.
+func (p Policy) Apply(result *SynthesizedResult, experts map[string]*expert.Expert) {
+	if p.Name == "" {
+		return
+	}
+	result.Policy = p.String()
+	if len(result.Perspectives) == 0 {
+		return
+	}
+
+	verdict := p.Resolve(result.Perspectives, experts)
+	if p.strict() && result.Verdict.Severity() > verdict.Severity() {
+		verdict = result.Verdict
+	}
+	if verdict == result.Verdict {
+		return
+	}
+	result.Verdict = verdict
+	if result.Summary != "" {
+		result.Summary += " "
+	}
+	result.Summary += fmt.Sprintf("Resolved to %s under the %s policy.", verdict, p.Name)
+}
+
+// weightedMajority returns the most severe verdict that experts carrying
+// more than half the total weight reach or exceed. When every weight is
+// zero, each expert counts once.
+func weightedMajority(verdicts []ExpertVerdict, weight func(ExpertVerdict) float64) Verdict {
+	var valid []ExpertVerdict
+	total := 0.0
+	for _, v := range verdicts {
+		if v.Error == "" {
+			valid = append(valid, v)
+			total += weight(v)
+		}
+	}
+	if total == 0 {
+		weight = func(ExpertVerdict) float64 { return 1 }
+		total = float64(len(valid))
+	}
+
+	result := VerdictPass
+	for _, candidate := range []Verdict{VerdictComment, VerdictBlock, VerdictEscalate} {
+		reached := 0.0
+		for _, v := range valid {
+			if v.Verdict.Severity() >= candidate.Severity() {
+				reached += weight(v)
+			}
+		}
+		if reached > total/2 {
+			result = candidate
+		}
+	}
+	return result
+}
+
+// capAtComment lowers block and escalate to comment.
+func capAtComment(v Verdict) Verdict {
+	if v.Severity() > VerdictComment.Severity() {
+		return VerdictComment
+	}
+	return v
+}
@@ -0,0 +1,129 @@
+package review
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/luuuc/council/internal/expert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestPolicyResolve(t *testing.T) {
+	experts := map[string]*expert.Expert{
+		"sec":   {ID: "sec", Focus: "Application security"},
+		"ux":    {ID: "ux", Focus: "User experience"},
+		"tdd":   {ID: "tdd", Focus: "Test-driven development"},
+		"style": {ID: "style", Focus: "Code style"},
+	}
+	v := func(id string, verdict Verdict, confidence float64) ExpertVerdict {
+		return ExpertVerdict{Expert: id, Verdict: verdict, Confidence: confidence}
+	}
+
+	oneBlock := []ExpertVerdict{v("tdd", VerdictBlock, 0.9), v("style", VerdictPass, 0.5), v("ux", VerdictComment, 0.5)}
+	twoBlocks := []ExpertVerdict{v("tdd", VerdictBlock, 0.9), v("style", VerdictBlock, 0.2), v("ux", VerdictPass, 0.2), v("sec", VerdictPass, 0.3)}
+	secBlock := []ExpertVerdict{v("sec", VerdictBlock, 0.5), v("tdd", VerdictPass, 0.5)}
+
+	tests := []struct {
+		name     string
+		policy   Policy
+		verdicts []ExpertVerdict
+		want     Verdict
+	}{
+		{"zero policy is strict", Policy{}, oneBlock, VerdictBlock},
+		{"strict", Policy{Name: PolicyStrict}, oneBlock, VerdictBlock},
+		{"majority", Policy{Name: PolicyMajority}, oneBlock, VerdictComment},
+		{"majority needs more than half", Policy{Name: PolicyMajority}, twoBlocks, VerdictPass},
+		{"confidence-weighted", Policy{Name: PolicyConfidence}, twoBlocks, VerdictBlock},
+		{"confidence-weighted without confidence", Policy{Name: PolicyConfidence}, []ExpertVerdict{v("tdd", VerdictBlock, 0), v("ux", VerdictPass, 0), v("sec", VerdictPass, 0)}, VerdictPass},
+		{"domain-veto caps other domains", Policy{Name: PolicyDomainVeto}, oneBlock, VerdictComment},
+		{"domain-veto lets security block", Policy{Name: PolicyDomainVeto}, secBlock, VerdictBlock},
+		{"domain-veto custom domains", Policy{Name: PolicyDomainVeto, Veto: []string{"quality"}}, oneBlock, VerdictBlock},
+		{"quorum not reached", Policy{Name: PolicyQuorum}, oneBlock, VerdictComment},
+		{"quorum reached", Policy{Name: PolicyQuorum}, twoBlocks, VerdictBlock},
+		{"quorum of three", Policy{Name: PolicyQuorum, Quorum: 3}, twoBlocks, VerdictComment},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Resolve(tt.verdicts, experts); got != tt.want {
+				t.Errorf("Resolve() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicyIgnoresFailedExperts(t *testing.T) {
+	verdicts := []ExpertVerdict{
+		{Expert: "a", Verdict: VerdictBlock},
+		{Expert: "b", Verdict: VerdictBlock, Error: "timeout"},
+		{Expert: "c", Verdict: VerdictPass},
+	}
+	if got := (Policy{Name: PolicyQuorum}).Resolve(verdicts, nil); got != VerdictComment {
+		t.Errorf("a failed expert's block should not count toward the quorum, got %s", got)
+	}
+}
+
+func TestPolicyApply(t *testing.T) {
+	result := &SynthesizedResult{
+		Verdict: VerdictBlock,
+		Summary: "2 experts reviewed.",
+		Perspectives: []ExpertVerdict{
+			{Expert: "a", Verdict: VerdictBlock},
+			{Expert: "b", Verdict: VerdictPass},
+		},
+	}
+
+	Policy{}.Apply(result, nil)
+	if result.Policy != "" || result.Verdict != VerdictBlock {
+		t.Errorf("an undeclared policy should leave the result alone: %+v", result)
+	}
+
+	Policy{Name: PolicyQuorum}.Apply(result, nil)
+	if result.Policy != "quorum (2)" || result.Verdict != VerdictComment {
+		t.Errorf("Apply() = policy %q, verdict %s", result.Policy, result.Verdict)
+	}
+	if !strings.HasSuffix(result.Summary, "Resolved to comment under the quorum policy.") {
+		t.Errorf("summary should explain the change: %q", result.Summary)
+	}
+	if !strings.Contains(FormatHuman(result, "go", 2), "(policy: quorum (2))") {
+		t.Error("human output should show the policy")
+	}
+}
+
+func TestPolicyYAML(t *testing.T) {
+	var short, full Policy
+	if err := yaml.Unmarshal([]byte("domain-veto"), &short); err != nil || short.Name != PolicyDomainVeto {
+		t.Errorf("shorthand = %+v, %v", short, err)
+	}
+	if err := yaml.Unmarshal([]byte("name: domain-veto\nveto: [security, compliance]"), &full); err != nil {
+		t.Fatal(err)
+	}
+	if full.String() != "domain-veto (security, compliance)" {
+		t.Errorf("String() = %q", full.String())
+	}
+	if err := (Policy{Name: "loudest"}).Validate(); err == nil {
+		t.Error("unknown policy should not validate")
+	}
+}
+
+func TestRunnerAppliesPolicy(t *testing.T) {
+	backend := &MockBackend{CollectiveResult: &SynthesizedResult{
+		Verdict: VerdictBlock,
+		Perspectives: []ExpertVerdict{
+			{Expert: "a", Verdict: VerdictBlock},
+			{Expert: "b", Verdict: VerdictPass},
+			{Expert: "c", Verdict: VerdictPass},
+		},
+	}}
+	runner := &Runner{Backend: backend, Policy: Policy{Name: PolicyMajority}}
+	inputs := []ExpertInput{
+		{Expert: &expert.Expert{ID: "a"}},
+		{Expert: &expert.Expert{ID: "b"}},
+		{Expert: &expert.Expert{ID: "c"}},
+	}
+
+	result := runner.Run(context.Background(), inputs, Submission{Content: "diff"})
+	if result.Verdict != VerdictPass || result.Policy != "majority" {
+		t.Errorf("Run() = verdict %s, policy %q; want pass under majority", result.Verdict, result.Policy)
+	}
+}
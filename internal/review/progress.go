@@ -0,0 +1,52 @@
+package review
+
+import "context"
+
+// ProgressKind identifies the kind of progress event.
+type ProgressKind string
+
+const (
+	ProgressStarted   ProgressKind = "started"   // a backend call began
+	ProgressStreaming ProgressKind = "streaming" // response text is arriving
+	ProgressRetrying  ProgressKind = "retrying"  // a call failed transiently and will be retried
+	ProgressFallback  ProgressKind = "fallback"  // a backend failed and the next one in the fallback chain takes over
+	ProgressFinished  ProgressKind = "finished"  // an expert (or the collective call) produced a verdict
+	ProgressFailed    ProgressKind = "failed"    // an expert (or the collective call) errored
+)
+
+// CollectiveLabel is the Expert value of progress events for the single
+// collective call.
+const CollectiveLabel = "collective"
+
+// ProgressEvent reports one step of a running review.
+type ProgressEvent struct {
+	Kind    ProgressKind
+	Expert  string  // expert ID, or CollectiveLabel
+	Round   int     // debate round, 0 or 1 outside debates
+	Verdict Verdict // set on ProgressFinished
+	Bytes   int     // response bytes received so far, set on ProgressStreaming
+	Err     error   // set on ProgressFailed, ProgressRetrying and ProgressFallback
+	Backend string  // set on ProgressFallback: the backend taking over
+}
+
+// ProgressFunc receives progress events. It may be called concurrently from
+// several expert goroutines and must be safe for that.
+type ProgressFunc func(ProgressEvent)
+
+type progressKey struct{}
+
+// withProgress attaches a progress sink to ctx so backends can report
+// streaming progress without widening the Backend interface.
+func withProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	if fn == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+// reportProgress sends ev to the sink attached to ctx, if any.
+func reportProgress(ctx context.Context, ev ProgressEvent) {
+	if fn, ok := ctx.Value(progressKey{}).(ProgressFunc); ok {
+		fn(ev)
+	}
+}
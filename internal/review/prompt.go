@@ -7,6 +7,21 @@ import (
 	"github.com/luuuc/council/internal/expert"
 )
 
+// PromptVersion identifies the revision of the prompt templates in this file.
+// Bump it whenever a template changes so cached review results are invalidated.
+const PromptVersion = 3
+
+// findingSchema is the JSON shape of one finding, shared by both prompts.
+const findingSchema = `{"file":"<path from the diff, or empty>","start_line":<first line>,"end_line":<last line>,"severity":"<info|warning|error>","category":"<e.g. correctness, security, design, testing, performance>","message":"<observation>","suggestion":"<optional replacement code>"}`
+
+// findingFields documents the finding fields, shared by both prompts.
+const findingFields = `  - file, start_line, end_line: where the finding applies, using line numbers of the new version of the file; omit for general observations
+  - severity: "info" (worth knowing), "warning" (should be addressed), "error" (must be fixed)
+  - category: one or two words naming the kind of issue
+  - message: the observation itself
+  - suggestion: only when you can propose a concrete fix — the exact code that replaces lines start_line to end_line
+`
+
 var promptTemplate = template.Must(template.New("review-prompt").Parse(`You are {{.Expert.Name}}, reviewing code as part of a council review.
 
 ## Your Persona
@@ -22,18 +37,28 @@ var promptTemplate = template.Must(template.New("review-prompt").Parse(`You are
 ## Context
 
 {{.Submission.Context}}
+{{end}}{{if .Submission.Debate}}
+## Previous Round
+
+The council reviewed this submission independently. These were its verdicts:
+{{range .Submission.Debate}}
+### {{.Expert}}{{if eq .Expert $.Expert.ID}} (you){{end}}: {{.Verdict}}, confidence {{.Confidence}}
+{{range .AllFindings}}
+- {{.String}}{{end}}
+{{end}}
+Review the submission again in light of the other experts' positions. Keep your verdict where you still stand by it and push back on findings you disagree with, or revise it where they convinced you. Concede explicitly in your findings when you change your mind.
 {{end}}
 ## Response Format
 
 You MUST respond with ONLY a JSON object matching this exact schema. No markdown, no code fences, no explanation before or after.
 
-{"expert":"{{.Expert.ID}}","verdict":"<pass|comment|block|escalate>","confidence":<0.0-1.0>,"notes":["<observation 1>","<observation 2>"],"blocking":false}
+{"expert":"{{.Expert.ID}}","verdict":"<pass|comment|block|escalate>","confidence":<0.0-1.0>,"findings":[` + findingSchema + `],"blocking":false}
 
 Field definitions:
 - verdict: "pass" (no issues), "comment" (suggestions worth considering), "block" (must fix before shipping), "escalate" (beyond your expertise to judge)
 - confidence: how confident you are in your assessment, from 0.0 to 1.0
-- notes: specific observations from your area of expertise — be direct and concrete
-- blocking: true only if this is a blocking issue that must be resolved
+- findings: specific observations from your area of expertise — be direct and concrete
+` + findingFields + `- blocking: true only if this is a blocking issue that must be resolved
 
 Respond with ONLY the JSON object. Nothing else.`))
 
@@ -76,12 +101,12 @@ Review the submission from each expert's perspective. Experts should react to ea
 
 Respond with ONLY a JSON object matching this exact schema. No markdown, no code fences, no explanation before or after.
 
-{"verdict":"<pass|comment|block|escalate>","blocking":false,"perspectives":[{"expert":"<expert-id>","verdict":"<pass|comment|block|escalate>","confidence":<0.0-1.0>,"notes":["<observation>"],"blocking":false}],"agreements":["<things all experts agree on>"],"tension":"<where experts disagree and why>","summary":"<one-line recommendation>"}
+{"verdict":"<pass|comment|block|escalate>","blocking":false,"perspectives":[{"expert":"<expert-id>","verdict":"<pass|comment|block|escalate>","confidence":<0.0-1.0>,"findings":[` + findingSchema + `],"blocking":false}],"agreements":["<things all experts agree on>"],"tension":"<where experts disagree and why>","summary":"<one-line recommendation>"}
 
 Field definitions:
 - verdict: overall recommendation — "pass" (no issues), "comment" (suggestions), "block" (must fix), "escalate" (beyond expertise)
-- perspectives: one entry per expert with their individual assessment
-- agreements: observations that all experts share
+- perspectives: one entry per expert with their individual assessment and findings
+` + findingFields + `- agreements: observations that all experts share
 - tension: where experts disagree — articulate both sides
 - summary: one-line recommendation for the author
 
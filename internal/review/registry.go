@@ -0,0 +1,157 @@
+package review
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/luuuc/council/internal/config"
+)
+
+// BackendSpec selects the backend an expert reviews with: "cli" or "api",
+// the API provider and the model. Empty fields fall back to the configured
+// backend. It is set in expert frontmatter or on a pack member:
+//
+//	members:
+//	  - id: the-threat-modeler
+//	    provider: anthropic
+//	    model: claude-opus-4-1
+//	  - id: the-go-purist
+//	    provider: ollama
+//	    model: qwen2.5-coder
+type BackendSpec struct {
+	Backend  string `yaml:"backend,omitempty" json:"backend,omitempty"`   // "cli" or "api"
+	Provider string `yaml:"provider,omitempty" json:"provider,omitempty"` // API provider; implies the api backend
+	Model    string `yaml:"model,omitempty" json:"model,omitempty"`
+}
+
+// IsZero reports whether the spec overrides nothing.
+func (s BackendSpec) IsZero() bool {
+	return s == BackendSpec{}
+}
+
+// Validate checks the backend and provider names.
+func (s BackendSpec) Validate() error {
+	if s.Backend != "" && !slices.Contains(config.ValidBackends, s.Backend) {
+		return fmt.Errorf("unknown backend '%s': must be one of: %s", s.Backend, strings.Join(config.ValidBackends, ", "))
+	}
+	if s.Provider != "" && !slices.Contains(config.ValidProviders, s.Provider) {
+		return fmt.Errorf("unknown provider '%s': must be one of: %s", s.Provider, strings.Join(config.ValidProviders, ", "))
+	}
+	if s.Backend == "cli" && s.Provider != "" {
+		return fmt.Errorf("provider '%s' needs the api backend, not cli", s.Provider)
+	}
+	return nil
+}
+
+// Override returns s with the fields set in o replacing its own.
+func (s BackendSpec) Override(o BackendSpec) BackendSpec {
+	if o.Backend != "" {
+		s.Backend = o.Backend
+	}
+	if o.Provider != "" {
+		s.Provider = o.Provider
+	}
+	if o.Model != "" {
+		s.Model = o.Model
+	}
+	return s
+}
+
+// String describes the spec, e.g. "api:ollama/qwen2.5-coder".
+func (s BackendSpec) String() string {
+	var b strings.Builder
+	if s.Backend != "" {
+		b.WriteString(s.Backend + ":")
+	}
+	b.WriteString(s.Provider)
+	if s.Model != "" {
+		b.WriteString("/" + s.Model)
+	}
+	return b.String()
+}
+
+// BackendSpec returns the backend the input asks for: the expert's
+// frontmatter, overridden by the pack member's Backend.
+func (inp ExpertInput) BackendSpec() BackendSpec {
+	e := BackendSpec{Backend: inp.Expert.Backend, Provider: inp.Expert.Provider, Model: inp.Expert.Model}
+	return e.Override(inp.Backend)
+}
+
+// BackendFactory builds the backend for a spec.
+type BackendFactory func(BackendSpec) (Backend, error)
+
+// Registry builds and hands out the backends experts override theirs with.
+// Each distinct spec is built once and shared, so experts on the same model
+// share its rate limiting. It is safe for concurrent use.
+type Registry struct {
+	build    BackendFactory
+	mu       sync.Mutex
+	backends map[BackendSpec]registryEntry
+}
+
+type registryEntry struct {
+	backend Backend
+	err     error
+}
+
+// NewRegistry returns a registry building backends with build.
+func NewRegistry(build BackendFactory) *Registry {
+	return &Registry{build: build, backends: make(map[BackendSpec]registryEntry)}
+}
+
+// Get returns the backend for spec, building it on first use. A spec that
+// fails to build keeps failing with the same error.
+func (r *Registry) Get(spec BackendSpec) (Backend, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.backends[spec]
+	if !ok {
+		entry.backend, entry.err = r.build(spec)
+		r.backends[spec] = entry
+	}
+	return entry.backend, entry.err
+}
+
+// backendFor returns the backend an input reviews with: the default
+// Backend, or the one its BackendSpec selects from Backends.
+func (r *Runner) backendFor(inp ExpertInput) (Backend, error) {
+	spec := inp.BackendSpec()
+	if spec.IsZero() || r.Backends == nil {
+		return r.Backend, nil
+	}
+	return r.Backends.Get(spec)
+}
+
+// mixedBackends reports whether some input reviews with a backend of its
+// own, which rules out a single collective call.
+func (r *Runner) mixedBackends(inputs []ExpertInput) bool {
+	if r.Backends == nil {
+		return false
+	}
+	for _, inp := range inputs {
+		if !inp.BackendSpec().IsZero() {
+			return true
+		}
+	}
+	return false
+}
+
+// ReviewModel returns the model to record for result: model, or when its
+// perspectives came from several backends or models, each of them as
+// ExpertVerdict.Model names it, comma-separated.
+func ReviewModel(result *SynthesizedResult, model string) string {
+	perspectives := result.AllPerspectives()
+	if !mixedModels(perspectives) {
+		return model
+	}
+	var models []string
+	for _, p := range perspectives {
+		if p.Model != "" && !slices.Contains(models, p.Model) {
+			models = append(models, p.Model)
+		}
+	}
+	return strings.Join(models, ", ")
+}
@@ -0,0 +1,150 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/luuuc/council/internal/expert"
+)
+
+// namedBackend is a MockBackend with a name, standing in for a provider.
+type namedBackend struct {
+	MockBackend
+	name string
+}
+
+func (b *namedBackend) Name() string { return b.name }
+
+func TestRunner_PerExpertBackends(t *testing.T) {
+	defaultBackend := &namedBackend{name: "api:anthropic/claude-sonnet-4-6"}
+	builds := 0
+	registry := NewRegistry(func(spec BackendSpec) (Backend, error) {
+		builds++
+		if spec.Provider == "broken" {
+			return nil, errors.New("no API key")
+		}
+		return &namedBackend{
+			name:        "api:" + spec.Provider + "/" + spec.Model,
+			MockBackend: MockBackend{Results: map[string]ExpertVerdict{"rob": {Expert: "rob", Verdict: VerdictComment}}},
+		}, nil
+	})
+
+	inputs := []ExpertInput{
+		{Expert: &expert.Expert{ID: "kent"}},
+		{Expert: &expert.Expert{ID: "rob", Provider: "ollama", Model: "llama3"}},
+		{Expert: &expert.Expert{ID: "sandi", Provider: "ollama"}, Backend: BackendSpec{Model: "llama3"}},
+		{Expert: &expert.Expert{ID: "ward", Provider: "broken"}},
+	}
+	runner := &Runner{Backend: defaultBackend, Backends: registry}
+
+	result := runner.Run(context.Background(), inputs, Submission{Content: "diff"})
+
+	if defaultBackend.collectiveCalls.Load() != 0 {
+		t.Error("mixed backends can't share a collective call")
+	}
+	if builds != 2 {
+		t.Errorf("built %d backends, want one per distinct spec (2)", builds)
+	}
+
+	models := make(map[string]string)
+	for _, p := range result.Perspectives {
+		models[p.Expert] = p.Model
+	}
+	want := map[string]string{
+		"kent":  "api:anthropic/claude-sonnet-4-6",
+		"rob":   "api:ollama/llama3",
+		"sandi": "api:ollama/llama3",
+	}
+	for id, model := range want {
+		if models[id] != model {
+			t.Errorf("%s reviewed with %q, want %q", id, models[id], model)
+		}
+	}
+	if result.Verdict != VerdictComment {
+		t.Errorf("verdict = %s, want rob's comment from the ollama backend", result.Verdict)
+	}
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0], "ward: no API key") {
+		t.Errorf("errors = %v, want ward's backend failure", result.Errors)
+	}
+}
+
+func TestRunner_NoOverridesStaysCollective(t *testing.T) {
+	backend := &MockBackend{CollectiveResult: &SynthesizedResult{Verdict: VerdictPass, Perspectives: []ExpertVerdict{{Expert: "kent"}}}}
+	runner := &Runner{Backend: backend, Backends: NewRegistry(func(BackendSpec) (Backend, error) {
+		t.Error("no expert overrides its backend")
+		return nil, nil
+	})}
+
+	result := runner.Run(context.Background(), debateInputs("kent", "rob"), Submission{Content: "diff"})
+
+	if backend.collectiveCalls.Load() != 1 {
+		t.Error("expected the collective call")
+	}
+	if result.Perspectives[0].Model != "*review.MockBackend" {
+		t.Errorf("Model = %q, want the backend's name", result.Perspectives[0].Model)
+	}
+}
+
+func TestBackendSpec(t *testing.T) {
+	spec := BackendSpec{Provider: "anthropic", Model: "claude-sonnet-4-6"}.Override(BackendSpec{Model: "claude-opus-4-1"})
+	if got := spec.String(); got != "anthropic/claude-opus-4-1" {
+		t.Errorf("String() = %q", got)
+	}
+	if (BackendSpec{}).IsZero() != true || spec.IsZero() {
+		t.Error("IsZero() is wrong")
+	}
+
+	for _, bad := range []BackendSpec{{Backend: "grpc"}, {Provider: "acme"}, {Backend: "cli", Provider: "openai"}} {
+		if err := bad.Validate(); err == nil {
+			t.Errorf("Validate(%+v) expected an error", bad)
+		}
+	}
+	if err := (BackendSpec{Backend: "api", Provider: "ollama", Model: "llama3"}).Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestCacheKey_BackendSpec(t *testing.T) {
+	sub := Submission{Content: "diff"}
+	plain := []ExpertInput{{Expert: &expert.Expert{ID: "kent"}}}
+	routed := []ExpertInput{{Expert: &expert.Expert{ID: "kent"}, Backend: BackendSpec{Provider: "ollama"}}}
+
+	if CacheKey(plain, sub, "b", ReviewOptions{}) == CacheKey(routed, sub, "b", ReviewOptions{}) {
+		t.Error("an expert's backend override should change the cache key")
+	}
+}
+
+func TestFormatHuman_MixedModels(t *testing.T) {
+	result := &SynthesizedResult{Verdict: VerdictPass, Perspectives: []ExpertVerdict{
+		{Expert: "kent", Verdict: VerdictPass, Model: "api:anthropic/claude-sonnet-4-6"},
+		{Expert: "rob", Verdict: VerdictPass, Model: "api:ollama/llama3"},
+	}}
+
+	if out := FormatHuman(result, "", 2); !strings.Contains(out, "  (model: api:ollama/llama3)") {
+		t.Errorf("output should name each perspective's model:\n%s", out)
+	}
+
+	result.Perspectives[1].Model = result.Perspectives[0].Model
+	if out := FormatHuman(result, "", 2); strings.Contains(out, "(model:") {
+		t.Errorf("a single model needs no mention:\n%s", out)
+	}
+}
+
+func TestReviewModel(t *testing.T) {
+	result := &SynthesizedResult{Perspectives: []ExpertVerdict{
+		{Expert: "kent", Model: "api:anthropic/claude-sonnet-4-6"},
+		{Expert: "rob", Model: "api:ollama/llama3"},
+		{Expert: "ken", Model: "api:ollama/llama3"},
+	}}
+
+	if got, want := ReviewModel(result, "claude-sonnet-4-6"), "api:anthropic/claude-sonnet-4-6, api:ollama/llama3"; got != want {
+		t.Errorf("ReviewModel() = %q, want %q", got, want)
+	}
+
+	result.Perspectives = result.Perspectives[:1]
+	if got := ReviewModel(result, "claude-sonnet-4-6"); got != "claude-sonnet-4-6" {
+		t.Errorf("ReviewModel() = %q, want the backend's model", got)
+	}
+}
@@ -0,0 +1,179 @@
+package review
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luuuc/council/internal/config"
+)
+
+// APIErrorKind classifies a failed provider response.
+type APIErrorKind string
+
+const (
+	APIErrRateLimited APIErrorKind = "rate_limited" // 429: slow down and retry
+	APIErrOverloaded  APIErrorKind = "overloaded"   // 5xx/529: provider is struggling, retry later
+	APIErrAuth        APIErrorKind = "auth"         // 401/403: credentials missing or rejected
+	APIErrBadRequest  APIErrorKind = "bad_request"  // other 4xx: the request itself is wrong
+)
+
+// APIError is a non-200 response from a provider API.
+type APIError struct {
+	Kind       APIErrorKind
+	Provider   string
+	StatusCode int
+	Label      string        // expert ID or "collective"
+	Detail     string        // truncated response body
+	RetryAfter time.Duration // server-suggested wait, from Retry-After or rate-limit headers
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("API returned %d for %s", e.StatusCode, e.Label)
+
+	switch e.Kind {
+	case APIErrRateLimited:
+		msg += " (rate limited)"
+	case APIErrOverloaded:
+		msg += " (provider unavailable)"
+	case APIErrAuth:
+		if env := config.ProviderEnvKeys[e.Provider]; env != "" {
+			msg += fmt.Sprintf(" (authentication failed: check that %s is set and valid)", env)
+		} else {
+			msg += " (authentication failed)"
+		}
+	case APIErrBadRequest:
+		msg += " (request rejected: check the model name and submission size)"
+	}
+
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	return msg
+}
+
+// Retryable reports whether retrying the same request may succeed.
+func (e *APIError) Retryable() bool {
+	return e.Kind == APIErrRateLimited || e.Kind == APIErrOverloaded
+}
+
+// Unavailable reports whether err means the backend couldn't serve the
+// call at all: the provider was unreachable, overloaded or rate limiting, or
+// the CLI isn't installed. Such failures say nothing about the submission.
+func Unavailable(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Retryable()
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) || errors.Is(err, exec.ErrNotFound)
+}
+
+// classifyStatus maps an HTTP status code to an APIErrorKind.
+func classifyStatus(status int) APIErrorKind {
+	switch {
+	case status == http.StatusTooManyRequests:
+		return APIErrRateLimited
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return APIErrAuth
+	case status >= 500:
+		return APIErrOverloaded // includes Anthropic's 529 "overloaded"
+	default:
+		return APIErrBadRequest
+	}
+}
+
+// RetryPolicy controls how APIBackend retries retryable failures.
+type RetryPolicy struct {
+	MaxAttempts int           // total attempts including the first (<= 1 disables retries)
+	BaseDelay   time.Duration // first backoff step, doubled each attempt
+	MaxDelay    time.Duration // cap on the computed backoff (server hints may exceed it)
+}
+
+// DefaultRetryPolicy retries up to three times, backing off from 1s to 30s.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	BaseDelay:   time.Second,
+	MaxDelay:    30 * time.Second,
+}
+
+// backoff returns the wait before the retry following attempt (0-based).
+// The exponential step is jittered by ±50% so concurrent experts don't retry
+// in lockstep, and the wait is never shorter than the server's hint.
+func (p RetryPolicy) backoff(attempt int, hint time.Duration) time.Duration {
+	step := p.BaseDelay << attempt
+	if step <= 0 || step > p.MaxDelay {
+		step = p.MaxDelay
+	}
+
+	delay := time.Duration(0)
+	if step > 0 {
+		delay = time.Duration(rand.Int64N(int64(step))) + step/2
+	}
+	if hint > delay {
+		delay = hint
+	}
+	return delay
+}
+
+// rateLimitDelay reads how long the provider asks us to wait from the
+// response headers. It understands Retry-After (seconds or HTTP date),
+// retry-after-ms, OpenAI's x-ratelimit-reset-* durations, GitHub's
+// x-ratelimit-reset epoch, and Anthropic's anthropic-ratelimit-*-reset
+// timestamps. Returns the longest wait found, or zero.
+func rateLimitDelay(h http.Header, now time.Time) time.Duration {
+	var longest time.Duration
+	consider := func(d time.Duration) {
+		if d > longest {
+			longest = d
+		}
+	}
+
+	if v := h.Get("Retry-After"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil {
+			consider(time.Duration(secs) * time.Second)
+		} else if t, err := http.ParseTime(v); err == nil {
+			consider(t.Sub(now))
+		}
+	}
+	if v := h.Get("retry-after-ms"); v != "" {
+		if ms, err := strconv.ParseFloat(v, 64); err == nil {
+			consider(time.Duration(ms * float64(time.Millisecond)))
+		}
+	}
+
+	for _, name := range []string{"x-ratelimit-reset-requests", "x-ratelimit-reset-tokens"} {
+		if d, err := time.ParseDuration(h.Get(name)); err == nil {
+			consider(d)
+		}
+	}
+	if v := h.Get("x-ratelimit-reset"); v != "" {
+		if epoch, err := strconv.ParseInt(v, 10, 64); err == nil {
+			consider(time.Unix(epoch, 0).Sub(now))
+		}
+	}
+	for _, name := range []string{"anthropic-ratelimit-requests-reset", "anthropic-ratelimit-tokens-reset"} {
+		if t, err := time.Parse(time.RFC3339, h.Get(name)); err == nil {
+			consider(t.Sub(now))
+		}
+	}
+
+	return longest
+}
+
+// rateLimitExhausted reports whether a successful response says no requests
+// remain in the current window, so the next call should wait for the reset.
+func rateLimitExhausted(h http.Header) bool {
+	for _, name := range []string{"x-ratelimit-remaining-requests", "anthropic-ratelimit-requests-remaining", "x-ratelimit-remaining"} {
+		if strings.TrimSpace(h.Get(name)) == "0" {
+			return true
+		}
+	}
+	return false
+}
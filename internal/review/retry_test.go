@@ -0,0 +1,210 @@
+package review
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClassifyStatus(t *testing.T) {
+	tests := []struct {
+		status int
+		want   APIErrorKind
+	}{
+		{429, APIErrRateLimited},
+		{401, APIErrAuth},
+		{403, APIErrAuth},
+		{400, APIErrBadRequest},
+		{404, APIErrBadRequest},
+		{500, APIErrOverloaded},
+		{503, APIErrOverloaded},
+		{529, APIErrOverloaded},
+	}
+	for _, tt := range tests {
+		if got := classifyStatus(tt.status); got != tt.want {
+			t.Errorf("classifyStatus(%d) = %s, want %s", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestRateLimitDelay(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		header map[string]string
+		want   time.Duration
+	}{
+		{"none", nil, 0},
+		{"retry-after seconds", map[string]string{"Retry-After": "7"}, 7 * time.Second},
+		{"retry-after date", map[string]string{"Retry-After": now.Add(90 * time.Second).Format(http.TimeFormat)}, 90 * time.Second},
+		{"retry-after-ms", map[string]string{"retry-after-ms": "1500"}, 1500 * time.Millisecond},
+		{"openai reset", map[string]string{"x-ratelimit-reset-requests": "2s", "x-ratelimit-reset-tokens": "6m0s"}, 6 * time.Minute},
+		{"github epoch", map[string]string{"x-ratelimit-reset": "1767268830"}, 30 * time.Second},
+		{"anthropic reset", map[string]string{"anthropic-ratelimit-requests-reset": now.Add(5 * time.Second).Format(time.RFC3339)}, 5 * time.Second},
+		{"longest wins", map[string]string{"Retry-After": "3", "retry-after-ms": "10000"}, 10 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := http.Header{}
+			for k, v := range tt.header {
+				h.Set(k, v)
+			}
+			if got := rateLimitDelay(h, now); got != tt.want {
+				t.Errorf("rateLimitDelay = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	for attempt := 0; attempt < 6; attempt++ {
+		step := p.BaseDelay << attempt
+		if step > p.MaxDelay {
+			step = p.MaxDelay
+		}
+		for i := 0; i < 20; i++ {
+			d := p.backoff(attempt, 0)
+			if d < step/2 || d >= step*3/2 {
+				t.Fatalf("attempt %d: backoff %s outside [%s, %s)", attempt, d, step/2, step*3/2)
+			}
+		}
+	}
+
+	if d := p.backoff(0, 5*time.Second); d != 5*time.Second {
+		t.Errorf("server hint should win over a shorter backoff, got %s", d)
+	}
+}
+
+func TestAPIBackendRetriesRateLimit(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.Header().Set("retry-after-ms", "10")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = io.WriteString(w, `{"error":{"message":"rate limit exceeded"}}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"choices":[{"message":{"content":"{\"verdict\":\"pass\",\"confidence\":0.9,\"notes\":[]}"}}]}`)
+	}))
+	defer server.Close()
+
+	backend, err := newAPIBackendWithClient("github", "openai/gpt-4.1-mini", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend.SetBaseURL(server.URL)
+	backend.Retry = RetryPolicy{MaxAttempts: 4, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+	var retries atomic.Int32
+	ctx := withProgress(context.Background(), func(ev ProgressEvent) {
+		if ev.Kind == ProgressRetrying {
+			retries.Add(1)
+		}
+	})
+
+	verdict, err := backend.Review(ctx, testExpert(), testSubmission())
+	if err != nil {
+		t.Fatalf("expected success after retries, got %v", err)
+	}
+	if verdict.Verdict != VerdictPass {
+		t.Errorf("expected pass, got %s", verdict.Verdict)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 calls, got %d", calls.Load())
+	}
+	if retries.Load() != 2 {
+		t.Errorf("expected 2 retry events, got %d", retries.Load())
+	}
+}
+
+func TestAPIBackendGivesUpAfterMaxAttempts(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(529)
+		_, _ = io.WriteString(w, `{"type":"error","error":{"type":"overloaded_error"}}`)
+	}))
+	defer server.Close()
+
+	backend, err := newAPIBackendWithClient("anthropic", "claude-sonnet-4-6", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend.SetBaseURL(server.URL)
+	backend.Retry = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 2 * time.Millisecond}
+
+	_, err = backend.Review(context.Background(), testExpert(), testSubmission())
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		t.Fatalf("expected *APIError, got %T: %v", err, err)
+	}
+	if apiErr.Kind != APIErrOverloaded {
+		t.Errorf("expected overloaded, got %s", apiErr.Kind)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls.Load())
+	}
+}
+
+func TestAPIBackendAuthFailsFast(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = io.WriteString(w, `{"error":{"message":"invalid x-api-key"}}`)
+	}))
+	defer server.Close()
+
+	backend, err := newAPIBackendWithClient("anthropic", "claude-sonnet-4-6", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend.SetBaseURL(server.URL)
+	backend.Retry = RetryPolicy{MaxAttempts: 4, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+
+	_, err = backend.Review(context.Background(), testExpert(), testSubmission())
+	if err == nil {
+		t.Fatal("expected error for 401 response")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("auth errors should not be retried, got %d calls", calls.Load())
+	}
+	if !strings.Contains(err.Error(), "ANTHROPIC_API_KEY") {
+		t.Errorf("error should name the env var, got: %s", err)
+	}
+}
+
+func TestAPIBackendRetryRespectsDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	backend, err := newAPIBackendWithClient("openai", "gpt-4o", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend.SetBaseURL(server.URL)
+	backend.Retry = DefaultRetryPolicy
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	_, err = backend.Review(ctx, testExpert(), testSubmission())
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("should give up immediately when Retry-After exceeds the deadline, took %s", elapsed)
+	}
+}
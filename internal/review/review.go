@@ -39,36 +39,64 @@ func (v Verdict) Severity() int {
 
 // ExpertVerdict is the structured output from a single expert review.
 type ExpertVerdict struct {
-	Expert     string   `json:"expert"`
-	Verdict    Verdict  `json:"verdict"`
-	Confidence float64  `json:"confidence"`
-	Notes      []string `json:"notes"`
-	Blocking   bool     `json:"blocking"`
-	Error      string   `json:"error,omitempty"`
+	Expert     string    `json:"expert"`
+	Verdict    Verdict   `json:"verdict"`
+	Confidence float64   `json:"confidence"`
+	Notes      []string  `json:"notes"`              // free-text observations; the fallback when a model returns no findings
+	Findings   []Finding `json:"findings,omitempty"` // structured observations, see AllFindings
+	Blocking   bool      `json:"blocking"`
+	File       string    `json:"file,omitempty"`     // set in per-file review: the file this verdict covers
+	Council    string    `json:"council,omitempty"`  // set in routed review: the council that reviewed File
+	Model      string    `json:"model,omitempty"`    // the backend and model that produced the verdict, see BackendName
+	Fallback   bool      `json:"fallback,omitempty"` // Model is a fallback backend standing in for a failed one, see FallbackBackend
+	Usage      *Usage    `json:"usage,omitempty"`    // tokens and cost of the calls behind this verdict; unset for CLI and collective reviews
+	Error      string    `json:"error,omitempty"`
 }
 
 // Submission is the material being reviewed.
 type Submission struct {
-	Content   string // The diff, file content, or text to review
-	Context   string // Optional context (e.g., PR title)
-	RawPrompt string // When set, backends use this as the prompt directly (bypasses BuildPrompt and ParseVerdict)
+	Content   string          // The diff, file content, or text to review
+	Context   string          // Optional context (e.g., PR title)
+	RawPrompt string          // When set, backends use this as the prompt directly (bypasses BuildPrompt and ParseVerdict)
+	Debate    []ExpertVerdict // Set in debate rounds after the first: every verdict of the previous round
 }
 
 // SynthesizedResult is the aggregated output from all expert reviews.
 type SynthesizedResult struct {
-	Verdict      Verdict         `json:"verdict"`
-	Blocking     bool            `json:"blocking"`
-	Perspectives []ExpertVerdict `json:"perspectives"`
-	Agreements   []string        `json:"agreements"`
-	Tension      string          `json:"tension"`
-	Summary      string          `json:"summary"`
-	Errors       []string        `json:"errors,omitempty"`
+	Verdict      Verdict          `json:"verdict"`
+	Policy       string           `json:"policy,omitempty"` // verdict policy declared by the pack, see Policy
+	Blocking     bool             `json:"blocking"`
+	Perspectives []ExpertVerdict  `json:"perspectives"`
+	Agreements   []string         `json:"agreements"`
+	Clusters     []FindingCluster `json:"clusters,omitempty"` // findings several experts raised, see ClusterFindings
+	Tension      string           `json:"tension"`
+	Summary      string           `json:"summary"`
+	Errors       []string         `json:"errors,omitempty"`
+	Unavailable  bool             `json:"unavailable,omitempty"` // every call failed because the backend couldn't be reached, see Unavailable
+	Skipped      []SkippedFile    `json:"skipped,omitempty"`
+	Filtered     []SkippedFile    `json:"filtered,omitempty"` // files removed by path rules before review
+	Routing      []FileRoute      `json:"routing,omitempty"`  // set in routed review: which council reviewed each file
+	Cached       bool             `json:"cached,omitempty"`   // served from the review cache
+	Reviewed     string           `json:"reviewed,omitempty"` // set when reviewing a branch: the commit the review covers everything up to
+	Since        string           `json:"since,omitempty"`    // set in incremental review: the commit reviewed last time
+	Carried      []ExpertVerdict  `json:"carried,omitempty"`  // set in incremental review: findings on files unchanged since, see CarryForward
+	Debate       *Debate          `json:"debate,omitempty"`   // set in multi-round review: how verdicts moved between rounds
+	Chaired      bool             `json:"chaired,omitempty"`  // agreements, tension, summary and verdict written by the chair, see ChairDecision
+	Usage        *Usage           `json:"usage,omitempty"`    // tokens and cost of every call the review made
+}
+
+// SkippedFile records a file left out of a review and why.
+type SkippedFile struct {
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
 }
 
 // ReviewOptions controls review execution.
 type ReviewOptions struct {
 	Concurrency int
-	Timeout     int // per-expert timeout in seconds
+	Timeout     int  // per-expert timeout in seconds
+	Rounds      int  // debate rounds; above 1, experts review blind and then answer each other
+	Chair       bool // review per expert, then have one more call synthesize the verdicts
 }
 
 // DefaultConcurrency is the default number of parallel expert reviews.
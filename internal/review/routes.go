@@ -0,0 +1,103 @@
+package review
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultCouncil labels files that no route matches. They are reviewed by
+// the default council (every expert).
+const DefaultCouncil = "default"
+
+// Route sends files matching any of Paths to a pack or to a list of experts.
+type Route struct {
+	Paths   []string `yaml:"paths"`             // gitignore-style globs, see PathRules
+	Pack    string   `yaml:"pack,omitempty"`    // pack reviewing the matched files
+	Experts []string `yaml:"experts,omitempty"` // or an ad-hoc council of expert IDs
+}
+
+// Council returns the label used in output for the council this route selects.
+func (r Route) Council() string {
+	if r.Pack != "" {
+		return r.Pack
+	}
+	return strings.Join(r.Experts, "+")
+}
+
+// Routes maps file paths to councils, CODEOWNERS-style: when several routes
+// match a file, the last one wins.
+type Routes struct {
+	Routes []Route `yaml:"routes"`
+}
+
+// ParseRoutes parses and validates a routes file.
+func ParseRoutes(data []byte) (*Routes, error) {
+	var rt Routes
+	if err := yaml.Unmarshal(data, &rt); err != nil {
+		return nil, fmt.Errorf("failed to parse routes: %w", err)
+	}
+
+	for i, r := range rt.Routes {
+		if len(r.Paths) == 0 {
+			return nil, fmt.Errorf("route %d: paths is required", i+1)
+		}
+		if (r.Pack == "") == (len(r.Experts) == 0) {
+			return nil, fmt.Errorf("route %d: set exactly one of pack or experts", i+1)
+		}
+	}
+	return &rt, nil
+}
+
+// LoadRoutes reads a routes file. Returns nil without error when the file
+// does not exist.
+func LoadRoutes(path string) (*Routes, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read routes: %w", err)
+	}
+	return ParseRoutes(data)
+}
+
+// Match returns the route for path, or nil when no route matches.
+func (rt *Routes) Match(path string) *Route {
+	var match *Route
+	for i := range rt.Routes {
+		if firstMatch(rt.Routes[i].Paths, path) != "" {
+			match = &rt.Routes[i]
+		}
+	}
+	return match
+}
+
+// RouteGroup is the set of files one council reviews.
+type RouteGroup struct {
+	Council string
+	Inputs  []ExpertInput
+	Files   []FileDiff
+	Policy  Policy // the pack's verdict policy
+}
+
+// FileRoute records which council reviewed a file.
+type FileRoute struct {
+	Path    string `json:"path"`
+	Council string `json:"council"`
+}
+
+// groupRouting groups routed files by council, in first-seen order.
+func groupRouting(routing []FileRoute) ([]string, map[string][]string) {
+	var councils []string
+	files := make(map[string][]string)
+	for _, r := range routing {
+		if _, ok := files[r.Council]; !ok {
+			councils = append(councils, r.Council)
+		}
+		files[r.Council] = append(files[r.Council], r.Path)
+	}
+	return councils, files
+}
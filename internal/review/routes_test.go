@@ -0,0 +1,69 @@
+package review
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseRoutes(t *testing.T) {
+	rt, err := ParseRoutes([]byte(`routes:
+  - paths: [app/, lib/]
+    pack: rails
+  - paths: [services/]
+    pack: go
+  - paths: [services/billing/]
+    experts: [the-threat-modeler, the-go-purist]
+`))
+	if err != nil {
+		t.Fatalf("ParseRoutes() error = %v", err)
+	}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"app/models/user.rb", "rails"},
+		{"services/api/main.go", "go"},
+		{"services/billing/charge.go", "the-threat-modeler+the-go-purist"}, // last match wins
+		{"README.md", ""},
+	}
+	for _, tt := range tests {
+		got := ""
+		if r := rt.Match(tt.path); r != nil {
+			got = r.Council()
+		}
+		if got != tt.want {
+			t.Errorf("Match(%q) council = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestParseRoutesInvalid(t *testing.T) {
+	tests := map[string]string{
+		"no paths":     "routes:\n  - pack: rails\n",
+		"no council":   "routes:\n  - paths: [app/]\n",
+		"two councils": "routes:\n  - paths: [app/]\n    pack: rails\n    experts: [a]\n",
+		"bad yaml":     "routes: [broken",
+	}
+	for name, input := range tests {
+		if _, err := ParseRoutes([]byte(input)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestLoadRoutes(t *testing.T) {
+	rt, err := LoadRoutes(filepath.Join(t.TempDir(), "routes.yaml"))
+	if err != nil || rt != nil {
+		t.Errorf("LoadRoutes() on a missing file = %v, %v; want nil, nil", rt, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "routes.yaml")
+	if err := os.WriteFile(path, []byte("routes:\n  - paths: [app/]\n    pack: rails\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if rt, err := LoadRoutes(path); err != nil || len(rt.Routes) != 1 {
+		t.Errorf("LoadRoutes() = %v, %v; want one route", rt, err)
+	}
+}
@@ -2,8 +2,10 @@ package review
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"slices"
 	"sync"
 	"time"
 
@@ -12,14 +14,20 @@ import (
 
 // Runner orchestrates expert reviews.
 type Runner struct {
-	Backend Backend
-	Options ReviewOptions
+	Backend  Backend
+	Options  ReviewOptions
+	Progress ProgressFunc // optional: receives per-expert progress as the review runs
+	Cache    *Cache       // optional: reuse results for identical reviews
+	Policy   Policy       // optional: how verdicts combine; strict when zero
+	Backends *Registry    // optional: backends for experts that override theirs, see ExpertInput.BackendSpec
+	Budget   *Budget      // optional: stop calling priced APIs before the review costs more
 }
 
 // ExpertInput pairs an expert with their blocking status from the pack.
 type ExpertInput struct {
 	Expert   *expert.Expert
 	Blocking bool
+	Backend  BackendSpec // pack member's backend override, see BackendSpec
 }
 
 // CollectiveThreshold is the byte-count threshold for the collective prompt.
@@ -29,8 +37,65 @@ const CollectiveThreshold = 32 * 1024
 
 // Run executes a collective review by default (one LLM call with all experts).
 // Falls back to per-expert concurrent review when a single expert is specified
-// or the estimated collective prompt exceeds CollectiveThreshold.
+// or the estimated collective prompt exceeds CollectiveThreshold. With
+// Options.Rounds above 1, the experts debate instead (see runDebate); with
+// Options.Chair, they review on their own and the chair synthesizes their
+// verdicts (see runChair).
+//
+// When a Cache is set, an identical earlier review is returned without
+// calling the Backend, and results without errors are stored for next time.
+// The overall verdict is resolved under Policy. Calls the Budget refuses
+// are reported as errors.
 func (r *Runner) Run(ctx context.Context, inputs []ExpertInput, sub Submission) *SynthesizedResult {
+	result := r.runCached(withBudget(withProgress(ctx, r.Progress), r.Budget), inputs, sub)
+
+	byID := make(map[string]*expert.Expert, len(inputs))
+	for _, inp := range inputs {
+		byID[inp.Expert.ID] = inp.Expert
+	}
+	r.Policy.Apply(result, byID)
+	return result
+}
+
+// runCached serves a review from the cache when there is one. The verdict
+// policy is applied afterwards, so it isn't part of the cache key. Failed
+// reviews, and those the chair was asked to synthesize but didn't, are not
+// cached, so the next run tries again.
+func (r *Runner) runCached(ctx context.Context, inputs []ExpertInput, sub Submission) *SynthesizedResult {
+	if r.Cache == nil {
+		return r.run(ctx, inputs, sub)
+	}
+
+	key := CacheKey(inputs, sub, BackendName(r.Backend), r.Options)
+	if cached, ok := r.Cache.Get(key); ok {
+		// Nothing was spent this time; the stored usage is the first run's
+		cached.Cached = true
+		cached.Usage = nil
+		for i := range cached.Perspectives {
+			cached.Perspectives[i].Usage = nil
+		}
+		return cached
+	}
+
+	result := r.run(ctx, inputs, sub)
+	if len(result.Errors) == 0 && !hasPerspectiveErrors(result) && !r.chairFailed(result, sub) {
+		if err := r.Cache.Put(key, result); err != nil {
+			log.Printf("failed to cache review result: %s", err)
+		}
+	}
+	return result
+}
+
+// run picks the debate, collective or per-expert path for a review. With
+// Options.Chair, the per-expert or debate result goes to the chair.
+func (r *Runner) run(ctx context.Context, inputs []ExpertInput, sub Submission) *SynthesizedResult {
+	if r.Options.Rounds > 1 && len(inputs) > 1 && sub.RawPrompt == "" {
+		return r.chaired(ctx, r.runDebate(ctx, inputs, sub), inputs, sub)
+	}
+	if (r.Options.Chair || r.mixedBackends(inputs)) && sub.RawPrompt == "" {
+		return r.chaired(ctx, r.runPerExpert(ctx, inputs, sub), inputs, sub)
+	}
+
 	if len(inputs) == 1 {
 		return r.runPerExpert(ctx, inputs, sub)
 	}
@@ -43,6 +108,45 @@ func (r *Runner) Run(ctx context.Context, inputs []ExpertInput, sub Submission)
 	return r.runCollective(ctx, inputs, sub)
 }
 
+// chaired hands result to the chair when Options.Chair is set.
+func (r *Runner) chaired(ctx context.Context, result *SynthesizedResult, inputs []ExpertInput, sub Submission) *SynthesizedResult {
+	if !r.Options.Chair {
+		return result
+	}
+	return r.runChair(ctx, result, inputs, sub)
+}
+
+// chairFailed reports whether the chair was due to synthesize result but
+// the heuristic synthesis stands instead.
+func (r *Runner) chairFailed(result *SynthesizedResult, sub Submission) bool {
+	return r.Options.Chair && sub.RawPrompt == "" && len(result.Perspectives) > 0 && !result.Chaired
+}
+
+// callTimeout returns how long one review call on b may take:
+// Options.Timeout, 120 seconds when unset, or for a fallback chain with
+// per-backend deadlines, long enough for every backend to use its own.
+func (r *Runner) callTimeout(b Backend) time.Duration {
+	if f, ok := b.(*FallbackBackend); ok && f.Timeout > 0 {
+		return f.Timeout * time.Duration(len(f.Backends))
+	}
+	timeout := time.Duration(r.Options.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = 120 * time.Second
+	}
+	return timeout
+}
+
+// hasPerspectiveErrors reports whether any perspective failed to parse;
+// such results are worth retrying rather than caching.
+func hasPerspectiveErrors(result *SynthesizedResult) bool {
+	for _, p := range result.Perspectives {
+		if p.Error != "" {
+			return true
+		}
+	}
+	return false
+}
+
 // estimateCollectiveSize approximates the collective prompt size in bytes
 // without building the full string. Sums expert content + submission + template overhead.
 func estimateCollectiveSize(inputs []ExpertInput, sub Submission) int {
@@ -57,21 +161,21 @@ func estimateCollectiveSize(inputs []ExpertInput, sub Submission) int {
 // runCollective executes a single collective LLM call.
 // Falls back to per-expert review if the collective call fails.
 func (r *Runner) runCollective(ctx context.Context, inputs []ExpertInput, sub Submission) *SynthesizedResult {
-	timeout := time.Duration(r.Options.Timeout) * time.Second
-	if timeout <= 0 {
-		timeout = 120 * time.Second
-	}
-
 	experts := make([]*expert.Expert, len(inputs))
 	for i, inp := range inputs {
 		experts[i] = inp.Expert
 	}
 
-	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	callCtx, cancel := context.WithTimeout(ctx, r.callTimeout(r.Backend))
 	defer cancel()
 
+	reportProgress(ctx, ProgressEvent{Kind: ProgressStarted, Expert: CollectiveLabel})
 	result, err := r.Backend.ReviewCollective(callCtx, experts, sub)
 	if err != nil {
+		reportProgress(ctx, ProgressEvent{Kind: ProgressFailed, Expert: CollectiveLabel, Err: err})
+		if !fallbackHelps(err) {
+			return collectiveFailure(err)
+		}
 		log.Printf("collective review failed, falling back to per-expert: %s", err)
 		return r.runPerExpert(ctx, inputs, sub)
 	}
@@ -83,40 +187,71 @@ func (r *Runner) runCollective(ctx context.Context, inputs []ExpertInput, sub Su
 	}
 	for i := range result.Perspectives {
 		result.Perspectives[i].Blocking = blockingByID[result.Perspectives[i].Expert]
+		if result.Perspectives[i].Model == "" {
+			result.Perspectives[i].Model = BackendName(r.Backend)
+		}
 	}
 
 	// Validate overall verdict against hierarchy
-	byID := make(map[string]*expert.Expert, len(experts))
-	for _, e := range experts {
-		byID[e.ID] = e
-	}
-	hierarchyVerdict := ResolveOverallVerdict(result.Perspectives, byID)
+	hierarchyVerdict := ResolveOverallVerdict(result.Perspectives)
 	if hierarchyVerdict.Severity() > result.Verdict.Severity() {
 		result.Verdict = hierarchyVerdict
 	}
 	result.Blocking = ResolveBlocking(result.Perspectives)
 
+	for _, p := range result.Perspectives {
+		reportProgress(ctx, ProgressEvent{Kind: ProgressFinished, Expert: p.Expert, Verdict: p.Verdict})
+	}
+
 	return result
 }
 
+// fallbackHelps reports whether retrying a failed collective call as N
+// per-expert calls could succeed. Rate limits, outages and bad credentials
+// only get worse with more requests; a rejected request may just be too big.
+func fallbackHelps(err error) bool {
+	if errors.Is(err, ErrBudgetExceeded) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Kind == APIErrBadRequest
+	}
+	return true
+}
+
+// collectiveFailure reports a collective call that failed without fallback.
+func collectiveFailure(err error) *SynthesizedResult {
+	return &SynthesizedResult{
+		Verdict:     VerdictPass,
+		Errors:      []string{fmt.Sprintf("%s: %s", CollectiveLabel, err)},
+		Unavailable: Unavailable(err),
+		Summary:     "Collective review failed; per-expert fallback skipped to avoid repeating the failure.",
+	}
+}
+
 // runPerExpert executes reviews in parallel with bounded concurrency (fallback path).
 func (r *Runner) runPerExpert(ctx context.Context, inputs []ExpertInput, sub Submission) *SynthesizedResult {
+	results := r.reviewEach(ctx, inputs, 1, func(int) Submission { return sub })
+	return synthesizeResults(inputs, results)
+}
+
+// expertResult is one expert's verdict, or the error that prevented it.
+type expertResult struct {
+	verdict ExpertVerdict
+	err     error
+}
+
+// reviewEach calls Review for every input in parallel with bounded
+// concurrency, with the submission subFor returns for that input. round
+// is reported in progress events.
+func (r *Runner) reviewEach(ctx context.Context, inputs []ExpertInput, round int, subFor func(i int) Submission) []expertResult {
 	concurrency := r.Options.Concurrency
 	if concurrency <= 0 {
 		concurrency = DefaultConcurrency
 	}
 
-	timeout := time.Duration(r.Options.Timeout) * time.Second
-	if timeout <= 0 {
-		timeout = 120 * time.Second
-	}
-
-	type result struct {
-		verdict ExpertVerdict
-		err     error
-	}
-
-	results := make([]result, len(inputs))
+	results := make([]expertResult, len(inputs))
 	sem := make(chan struct{}, concurrency)
 	var wg sync.WaitGroup
 
@@ -128,36 +263,145 @@ func (r *Runner) runPerExpert(ctx context.Context, inputs []ExpertInput, sub Sub
 			sem <- struct{}{}
 			defer func() { <-sem }()
 
-			expertCtx, cancel := context.WithTimeout(ctx, timeout)
-			defer cancel()
-
-			verdict, err := r.Backend.Review(expertCtx, inp.Expert, sub)
+			reportProgress(ctx, ProgressEvent{Kind: ProgressStarted, Expert: inp.Expert.ID, Round: round})
+			backend, err := r.backendFor(inp)
+			var verdict ExpertVerdict
+			if err == nil {
+				expertCtx, cancel := context.WithTimeout(ctx, r.callTimeout(backend))
+				verdict, err = backend.Review(expertCtx, inp.Expert, subFor(idx))
+				cancel()
+			}
 			if err != nil {
-				results[idx] = result{
+				reportProgress(ctx, ProgressEvent{Kind: ProgressFailed, Expert: inp.Expert.ID, Round: round, Err: err})
+				results[idx] = expertResult{
 					err: fmt.Errorf("%s: %w", inp.Expert.ID, err),
 				}
 				return
 			}
 
 			verdict.Blocking = inp.Blocking
-			results[idx] = result{verdict: verdict}
+			if verdict.Model == "" {
+				verdict.Model = BackendName(backend)
+			}
+			reportProgress(ctx, ProgressEvent{Kind: ProgressFinished, Expert: inp.Expert.ID, Round: round, Verdict: verdict.Verdict})
+			results[idx] = expertResult{verdict: verdict}
 		}(i, input)
 	}
 
 	wg.Wait()
+	return results
+}
 
+// synthesizeResults synthesizes the per-expert results of inputs.
+func synthesizeResults(inputs []ExpertInput, results []expertResult) *SynthesizedResult {
 	var verdicts []ExpertVerdict
 	var errors []string
 	experts := make([]*expert.Expert, 0, len(inputs))
+	unavailable := 0
 
 	for i, r := range results {
 		experts = append(experts, inputs[i].Expert)
 		if r.err != nil {
 			errors = append(errors, r.err.Error())
+			if Unavailable(r.err) {
+				unavailable++
+			}
 			continue
 		}
 		verdicts = append(verdicts, r.verdict)
 	}
 
-	return Synthesize(verdicts, experts, errors)
+	result := Synthesize(verdicts, experts, errors)
+	result.Usage = totalUsage(verdicts)
+	result.Unavailable = len(verdicts) == 0 && unavailable > 0 && unavailable == len(errors)
+	return result
+}
+
+// RunPerFile reviews each chunk's files in isolation, one council pass per
+// FileDiff, and merges the per-file results into one. Perspectives are tagged
+// with the file they cover; chunks.Skipped is carried into the result, along
+// with the files the budget or a cancelled ctx left unreviewed.
+func (r *Runner) RunPerFile(ctx context.Context, inputs []ExpertInput, chunks ChunkResult, sub Submission) *SynthesizedResult {
+	results, unreviewed := r.reviewFiles(ctx, inputs, chunks.Files, sub)
+	return MergeChunkedResults(results, slices.Concat(chunks.Skipped, unreviewed))
+}
+
+// RunRouted reviews each group's files with that group's council, one file
+// at a time, and merges everything into one result. Perspectives are tagged
+// with their council and Routing lists which council reviewed each file.
+func (r *Runner) RunRouted(ctx context.Context, groups []RouteGroup, skipped []FileDiff, sub Submission) *SynthesizedResult {
+	var results []*SynthesizedResult
+	var routing []FileRoute
+
+	for _, g := range groups {
+		council := *r
+		council.Policy = g.Policy
+		groupResults, unreviewed := council.reviewFiles(ctx, g.Inputs, g.Files, sub)
+		skipped = slices.Concat(skipped, unreviewed)
+		for _, result := range groupResults {
+			for i := range result.Perspectives {
+				result.Perspectives[i].Council = g.Council
+			}
+		}
+		results = append(results, groupResults...)
+		for _, f := range g.Files[:len(g.Files)-len(unreviewed)] {
+			routing = append(routing, FileRoute{Path: f.Path, Council: g.Council})
+		}
+	}
+
+	merged := MergeChunkedResults(results, skipped)
+	merged.Routing = routing
+	return merged
+}
+
+// reviewFiles runs the council on each file separately, tagging verdicts
+// and errors with the file path. Once the budget is spent or ctx is done,
+// the files left are returned as skipped instead, with the reason.
+func (r *Runner) reviewFiles(ctx context.Context, inputs []ExpertInput, files []FileDiff, sub Submission) ([]*SynthesizedResult, []FileDiff) {
+	results := make([]*SynthesizedResult, 0, len(files))
+
+	for i, f := range files {
+		var reason string
+		switch {
+		case errors.Is(ctx.Err(), context.DeadlineExceeded):
+			reason = "review timed out"
+		case ctx.Err() != nil:
+			reason = "review cancelled"
+		case r.Budget.Exceeded():
+			reason = "budget exceeded"
+		}
+		if reason != "" {
+			skipped := make([]FileDiff, 0, len(files)-i)
+			for _, left := range files[i:] {
+				left.Skipped = true
+				left.SkipReason = reason
+				skipped = append(skipped, left)
+			}
+			return results, skipped
+		}
+
+		fileSub := Submission{
+			Content: f.Diff,
+			Context: fmt.Sprintf("File: %s", f.Path),
+		}
+		if sub.Context != "" {
+			fileSub.Context = sub.Context + "\n" + fileSub.Context
+		}
+
+		result := r.Run(ctx, inputs, fileSub)
+		for i := range result.Perspectives {
+			result.Perspectives[i].File = f.Path
+		}
+		if result.Debate != nil {
+			for i := range result.Debate.Positions {
+				result.Debate.Positions[i].File = f.Path
+			}
+		}
+		for i, e := range result.Errors {
+			result.Errors[i] = f.Path + ": " + e
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -368,6 +369,30 @@ func TestRunnerPerExpertAllFail(t *testing.T) {
 	if len(result.Errors) != 1 {
 		t.Errorf("expected 1 error, got %d", len(result.Errors))
 	}
+	if result.Unavailable {
+		t.Error("a timeout is not an unavailable backend")
+	}
+}
+
+func TestRunnerPerExpertBackendUnavailable(t *testing.T) {
+	backend := &MockBackend{
+		Errors: map[string]error{
+			"kent-beck": &APIError{Kind: APIErrOverloaded, StatusCode: 529, Label: "kent-beck"},
+			"rob-pike":  &APIError{Kind: APIErrRateLimited, StatusCode: 429, Label: "rob-pike"},
+		},
+	}
+	runner := &Runner{Backend: backend}
+
+	result := runner.runPerExpert(context.Background(), debateInputs("kent-beck", "rob-pike"), Submission{Content: "test diff"})
+
+	if !result.AllFailed() || !result.Unavailable {
+		t.Errorf("AllFailed() = %v, Unavailable = %v; want both", result.AllFailed(), result.Unavailable)
+	}
+
+	backend.Errors["rob-pike"] = &APIError{Kind: APIErrBadRequest, StatusCode: 400, Label: "rob-pike"}
+	if result := runner.runPerExpert(context.Background(), debateInputs("kent-beck", "rob-pike"), Submission{Content: "test diff"}); result.Unavailable {
+		t.Error("a rejected request is not an unavailable backend")
+	}
 }
 
 func TestRunnerPerExpertContextCancellation(t *testing.T) {
@@ -451,3 +476,163 @@ func (c *concurrencyTracker) Review(ctx context.Context, e *expert.Expert, sub S
 func (c *concurrencyTracker) ReviewCollective(ctx context.Context, experts []*expert.Expert, sub Submission) (*SynthesizedResult, error) {
 	return c.inner.ReviewCollective(ctx, experts, sub)
 }
+
+func TestRunnerRunPerFile(t *testing.T) {
+	backend := &MockBackend{}
+	runner := &Runner{
+		Backend: backend,
+		Options: ReviewOptions{Timeout: 10},
+	}
+
+	inputs := []ExpertInput{
+		{Expert: &expert.Expert{ID: "a", Name: "A"}},
+		{Expert: &expert.Expert{ID: "b", Name: "B"}},
+	}
+
+	chunks := SplitDiff(makeDiff(3, 100), ChunkOptions{TokenBudget: 8000})
+	chunks.Skipped = append(chunks.Skipped, FileDiff{Path: "huge.go", Skipped: true, SkipReason: "file too large for per-file review"})
+
+	result := runner.RunPerFile(context.Background(), inputs, chunks, Submission{})
+
+	if backend.collectiveCalls.Load() != 3 {
+		t.Errorf("expected 3 collective calls (one per file), got %d", backend.collectiveCalls.Load())
+	}
+	if len(result.Perspectives) != 6 {
+		t.Fatalf("expected 6 perspectives, got %d", len(result.Perspectives))
+	}
+	for _, p := range result.Perspectives {
+		if p.File == "" {
+			t.Errorf("perspective %s has no file", p.Expert)
+		}
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0].Path != "huge.go" {
+		t.Errorf("expected huge.go to be reported as skipped, got %+v", result.Skipped)
+	}
+}
+
+func TestRunnerRunRouted(t *testing.T) {
+	backend := &MockBackend{}
+	runner := &Runner{
+		Backend: backend,
+		Options: ReviewOptions{Timeout: 10},
+	}
+
+	files := SplitDiff(makeDiff(3, 100), ChunkOptions{TokenBudget: 8000}).Files
+	groups := []RouteGroup{
+		{Council: "rails", Inputs: []ExpertInput{{Expert: &expert.Expert{ID: "a", Name: "A"}}}, Files: files[:2]},
+		{Council: "go", Inputs: []ExpertInput{{Expert: &expert.Expert{ID: "b", Name: "B"}}}, Files: files[2:]},
+	}
+
+	result := runner.RunRouted(context.Background(), groups, nil, Submission{})
+
+	if len(result.Perspectives) != 3 {
+		t.Fatalf("expected 3 perspectives (one per file), got %d", len(result.Perspectives))
+	}
+	for _, p := range result.Perspectives {
+		want := "rails"
+		if p.Expert == "b" {
+			want = "go"
+		}
+		if p.Council != want {
+			t.Errorf("perspective %s council = %q, want %q", p.Expert, p.Council, want)
+		}
+	}
+	if len(result.Routing) != 3 || result.Routing[2].Council != "go" || result.Routing[2].Path != files[2].Path {
+		t.Errorf("unexpected routing: %+v", result.Routing)
+	}
+}
+
+func TestRunnerRunRoutedCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	runner := &Runner{
+		Backend: &MockBackend{},
+		Options: ReviewOptions{Timeout: 10},
+		Progress: func(ev ProgressEvent) {
+			if ev.Kind == ProgressFinished {
+				cancel()
+			}
+		},
+	}
+
+	files := SplitDiff(makeDiff(3, 100), ChunkOptions{TokenBudget: 8000}).Files
+	groups := []RouteGroup{
+		{Council: "rails", Inputs: []ExpertInput{{Expert: &expert.Expert{ID: "a", Name: "A"}}}, Files: files[:2]},
+		{Council: "go", Inputs: []ExpertInput{{Expert: &expert.Expert{ID: "b", Name: "B"}}}, Files: files[2:]},
+	}
+
+	result := runner.RunRouted(ctx, groups, nil, Submission{})
+
+	if len(result.Routing) != 1 || result.Routing[0].Path != files[0].Path {
+		t.Errorf("routing = %+v, want only the file reviewed before the cancel", result.Routing)
+	}
+	if len(result.Skipped) != 2 {
+		t.Fatalf("skipped = %+v, want the two files left", result.Skipped)
+	}
+	for i, sf := range result.Skipped {
+		if sf.Path != files[i+1].Path || sf.Reason != "review cancelled" {
+			t.Errorf("skipped[%d] = %+v, want %s skipped as cancelled", i, sf, files[i+1].Path)
+		}
+	}
+}
+
+func TestRunnerReportsProgress(t *testing.T) {
+	backend := &MockBackend{
+		Errors: map[string]error{"b": fmt.Errorf("timeout")},
+	}
+
+	var mu sync.Mutex
+	events := make(map[ProgressKind][]string)
+	runner := &Runner{
+		Backend: backend,
+		Options: ReviewOptions{Timeout: 10},
+		Progress: func(ev ProgressEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+			events[ev.Kind] = append(events[ev.Kind], ev.Expert)
+		},
+	}
+
+	// Large body forces the per-expert path so each expert reports individually.
+	inputs := []ExpertInput{
+		{Expert: &expert.Expert{ID: "a", Name: "A", Body: strings.Repeat("x", CollectiveThreshold)}},
+		{Expert: &expert.Expert{ID: "b", Name: "B"}},
+	}
+
+	runner.Run(context.Background(), inputs, Submission{Content: "diff"})
+
+	if len(events[ProgressStarted]) != 2 {
+		t.Errorf("expected 2 started events, got %v", events[ProgressStarted])
+	}
+	if len(events[ProgressFinished]) != 1 || events[ProgressFinished][0] != "a" {
+		t.Errorf("expected a to finish, got %v", events[ProgressFinished])
+	}
+	if len(events[ProgressFailed]) != 1 || events[ProgressFailed][0] != "b" {
+		t.Errorf("expected b to fail, got %v", events[ProgressFailed])
+	}
+}
+
+func TestRunnerCollectiveRateLimitSkipsFallback(t *testing.T) {
+	backend := &MockBackend{
+		CollectiveErr: &APIError{Kind: APIErrRateLimited, Provider: "github", StatusCode: 429, Label: CollectiveLabel},
+	}
+
+	runner := &Runner{
+		Backend: backend,
+		Options: ReviewOptions{Timeout: 10},
+	}
+
+	inputs := []ExpertInput{
+		{Expert: &expert.Expert{ID: "a", Name: "A"}},
+		{Expert: &expert.Expert{ID: "b", Name: "B"}},
+	}
+
+	result := runner.Run(context.Background(), inputs, Submission{Content: "diff"})
+
+	if backend.calls.Load() != 0 {
+		t.Errorf("rate-limited collective call should not fan out to per-expert calls, got %d", backend.calls.Load())
+	}
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0], "429") {
+		t.Errorf("expected the 429 to be reported, got %v", result.Errors)
+	}
+}
@@ -0,0 +1,298 @@
+package review
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/luuuc/council/internal/expert"
+)
+
+// SARIFSchema is the JSON schema URI of SARIF 2.1.0 logs.
+const SARIFSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+// SARIFLog is a SARIF 2.1.0 log with a single run.
+type SARIFLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SARIFRun `json:"runs"`
+}
+
+// SARIFRun is one analysis run: the tool, its rules and the results.
+type SARIFRun struct {
+	Tool        SARIFTool         `json:"tool"`
+	Invocations []SARIFInvocation `json:"invocations"`
+	Results     []SARIFResult     `json:"results"`
+	Properties  map[string]any    `json:"properties,omitempty"`
+}
+
+// SARIFTool describes council as the analysis tool.
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+// SARIFDriver is the tool component that produced the results.
+type SARIFDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []SARIFRule `json:"rules"`
+}
+
+// SARIFRule describes one expert as a rule, keyed by domain and expert ID.
+type SARIFRule struct {
+	ID               string         `json:"id"`
+	Name             string         `json:"name,omitempty"`
+	ShortDescription SARIFMessage   `json:"shortDescription"`
+	Properties       map[string]any `json:"properties,omitempty"`
+}
+
+// SARIFInvocation records whether the review ran cleanly.
+type SARIFInvocation struct {
+	ExecutionSuccessful bool                `json:"executionSuccessful"`
+	Notifications       []SARIFNotification `json:"toolExecutionNotifications,omitempty"`
+}
+
+// SARIFNotification is an error raised while reviewing, or a file the
+// review left out.
+type SARIFNotification struct {
+	Level     string              `json:"level"`
+	Message   SARIFMessage        `json:"message"`
+	Locations []SARIFFileLocation `json:"locations,omitempty"`
+}
+
+// SARIFFileLocation points at a whole file.
+type SARIFFileLocation struct {
+	PhysicalLocation SARIFFile `json:"physicalLocation"`
+}
+
+// SARIFFile is a physical location without a region.
+type SARIFFile struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+}
+
+// SARIFMessage is a plain-text message.
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+// SARIFResult is one finding.
+type SARIFResult struct {
+	RuleID     string          `json:"ruleId"`
+	Level      string          `json:"level"`
+	Message    SARIFMessage    `json:"message"`
+	Locations  []SARIFLocation `json:"locations"`
+	Fixes      []SARIFFix      `json:"fixes,omitempty"`
+	Properties map[string]any  `json:"properties,omitempty"`
+}
+
+// SARIFLocation points at a region of a file.
+type SARIFLocation struct {
+	PhysicalLocation SARIFPhysicalLocation `json:"physicalLocation"`
+}
+
+// SARIFPhysicalLocation is a file and a line range in it.
+type SARIFPhysicalLocation struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+	Region           SARIFRegion           `json:"region"`
+}
+
+// SARIFArtifactLocation is a file path relative to the repository root.
+type SARIFArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// SARIFRegion is a 1-based, inclusive line range.
+type SARIFRegion struct {
+	StartLine int `json:"startLine"`
+	EndLine   int `json:"endLine,omitempty"`
+}
+
+// SARIFFix proposes a replacement for the result's region.
+type SARIFFix struct {
+	Description     SARIFMessage          `json:"description"`
+	ArtifactChanges []SARIFArtifactChange `json:"artifactChanges"`
+}
+
+// SARIFArtifactChange is a set of replacements in one file.
+type SARIFArtifactChange struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+	Replacements     []SARIFReplacement    `json:"replacements"`
+}
+
+// SARIFReplacement replaces a region with new content.
+type SARIFReplacement struct {
+	DeletedRegion   SARIFRegion  `json:"deletedRegion"`
+	InsertedContent SARIFMessage `json:"insertedContent"`
+}
+
+// SARIFMeta is the run metadata recorded alongside the results.
+type SARIFMeta struct {
+	Pack        string
+	Backend     string // see BackendName
+	Model       string
+	ToolVersion string
+}
+
+// FormatSARIF builds a SARIF 2.1.0 log from a review. Every finding anchored
+// to a file becomes a result whose rule is "<domain>/<expert-id>", with the
+// domain from ExpertDomain. Levels follow the finding's severity, or the
+// expert's verdict when the finding has none. Expert errors, and files the
+// review skipped or filtered out, are tool execution notifications.
+func FormatSARIF(result *SynthesizedResult, experts []*expert.Expert, meta SARIFMeta) SARIFLog {
+	byID := make(map[string]*expert.Expert, len(experts))
+	for _, e := range experts {
+		byID[e.ID] = e
+	}
+
+	rules := make(map[string]SARIFRule)
+	results := []SARIFResult{}
+
+	// Findings several experts raised are reported once, under the rule of
+	// the expert whose finding leads the cluster
+	for _, pf := range publishFindings(result.AllPerspectives()) {
+		p, f := pf.Perspective, pf.Finding
+		if f.File == "" || f.StartLine == 0 {
+			continue
+		}
+
+		e, ok := byID[p.Expert]
+		if !ok {
+			e = &expert.Expert{ID: p.Expert}
+		}
+		domain := ExpertDomain(e)
+		ruleID := domain.String() + "/" + p.Expert
+		if _, ok := rules[ruleID]; !ok {
+			rules[ruleID] = sarifRule(ruleID, e, domain)
+		}
+
+		region := SARIFRegion{StartLine: f.StartLine, EndLine: f.EndLine}
+		artifact := SARIFArtifactLocation{URI: f.File}
+		res := SARIFResult{
+			RuleID:  ruleID,
+			Level:   sarifLevel(effectiveSeverity(f, p.Verdict)),
+			Message: SARIFMessage{Text: f.Message},
+			Locations: []SARIFLocation{{
+				PhysicalLocation: SARIFPhysicalLocation{ArtifactLocation: artifact, Region: region},
+			}},
+			Properties: map[string]any{"verdict": p.Verdict},
+		}
+		if f.Category != "" {
+			res.Properties["category"] = f.Category
+		}
+		if len(pf.Experts) > 1 {
+			res.Properties["experts"] = pf.Experts
+		}
+		if f.Suggestion != "" {
+			res.Fixes = []SARIFFix{{
+				Description: SARIFMessage{Text: "Suggested by " + p.Expert},
+				ArtifactChanges: []SARIFArtifactChange{{
+					ArtifactLocation: artifact,
+					Replacements: []SARIFReplacement{{
+						DeletedRegion:   region,
+						InsertedContent: SARIFMessage{Text: f.Suggestion + "\n"},
+					}},
+				}},
+			}}
+		}
+		results = append(results, res)
+	}
+
+	ruleList := make([]SARIFRule, 0, len(rules))
+	for _, r := range rules {
+		ruleList = append(ruleList, r)
+	}
+	sort.Slice(ruleList, func(i, j int) bool { return ruleList[i].ID < ruleList[j].ID })
+
+	invocation := SARIFInvocation{ExecutionSuccessful: !result.AllFailed()}
+	for _, e := range result.Errors {
+		invocation.Notifications = append(invocation.Notifications, SARIFNotification{
+			Level:   "error",
+			Message: SARIFMessage{Text: e},
+		})
+	}
+	// Skipped files went unreviewed by accident, filtered ones by design
+	for _, f := range result.Skipped {
+		invocation.Notifications = append(invocation.Notifications, sarifFileNotification("warning", "Not reviewed", f))
+	}
+	for _, f := range result.Filtered {
+		invocation.Notifications = append(invocation.Notifications, sarifFileNotification("note", "Filtered out", f))
+	}
+
+	props := map[string]any{
+		"verdict":  result.Verdict,
+		"blocking": result.Blocking,
+		"backend":  meta.Backend,
+	}
+	if meta.Pack != "" {
+		props["pack"] = meta.Pack
+	}
+	if meta.Model != "" {
+		props["model"] = meta.Model
+	}
+	if result.Policy != "" {
+		props["policy"] = result.Policy
+	}
+
+	return SARIFLog{
+		Schema:  SARIFSchema,
+		Version: "2.1.0",
+		Runs: []SARIFRun{{
+			Tool: SARIFTool{Driver: SARIFDriver{
+				Name:           "council",
+				Version:        meta.ToolVersion,
+				InformationURI: "https://github.com/luuuc/council",
+				Rules:          ruleList,
+			}},
+			Invocations: []SARIFInvocation{invocation},
+			Results:     results,
+			Properties:  props,
+		}},
+	}
+}
+
+// FormatSARIFJSON marshals a SARIF log as indented JSON.
+func FormatSARIFJSON(log SARIFLog) ([]byte, error) {
+	return json.MarshalIndent(log, "", "  ")
+}
+
+// sarifRule describes an expert as a SARIF rule.
+func sarifRule(id string, e *expert.Expert, domain Domain) SARIFRule {
+	desc := e.ID
+	if e.Name != "" {
+		desc = e.Name
+	}
+	if e.Focus != "" {
+		desc += " — " + e.Focus
+	}
+	return SARIFRule{
+		ID:               id,
+		Name:             e.ID,
+		ShortDescription: SARIFMessage{Text: desc},
+		Properties:       map[string]any{"tags": []string{domain.String()}},
+	}
+}
+
+// sarifFileNotification reports a file left out of the review, e.g.
+// "Not reviewed: budget exceeded".
+func sarifFileNotification(level, what string, f SkippedFile) SARIFNotification {
+	return SARIFNotification{
+		Level:   level,
+		Message: SARIFMessage{Text: fmt.Sprintf("%s: %s", what, f.Reason)},
+		Locations: []SARIFFileLocation{{
+			PhysicalLocation: SARIFFile{ArtifactLocation: SARIFArtifactLocation{URI: f.Path}},
+		}},
+	}
+}
+
+// sarifLevel maps a finding severity to a SARIF result level.
+func sarifLevel(s FindingSeverity) string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
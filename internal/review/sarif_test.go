@@ -0,0 +1,174 @@
+package review
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/luuuc/council/internal/expert"
+)
+
+func TestFormatSARIF(t *testing.T) {
+	experts := []*expert.Expert{
+		{ID: "the-threat-modeler", Name: "Threat Modeler", Focus: "Security and threat modeling"},
+		{ID: "kent-beck", Name: "Kent Beck", Focus: "Test-driven development"},
+	}
+	result := &SynthesizedResult{
+		Verdict:  VerdictBlock,
+		Blocking: true,
+		Perspectives: []ExpertVerdict{
+			{Expert: "the-threat-modeler", Verdict: VerdictBlock, Findings: []Finding{{
+				File: "auth.go", StartLine: 10, EndLine: 12,
+				Category: "security", Message: "Token compared with ==", Suggestion: "subtle.ConstantTimeCompare(a, b)",
+			}}},
+			{Expert: "kent-beck", Verdict: VerdictComment, Notes: []string{
+				"auth_test.go:5: missing failure case",
+				"Consider a table test",
+			}},
+			{Expert: "unknown", Verdict: VerdictPass, Findings: []Finding{{
+				File: "main.go", StartLine: 1, Severity: SeverityInfo, Message: "Fine",
+			}}},
+		},
+	}
+
+	log := FormatSARIF(result, experts, SARIFMeta{Pack: "go", Backend: "api/anthropic", Model: "claude-x", ToolVersion: "1.0.0"})
+
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("version = %s, runs = %d", log.Version, len(log.Runs))
+	}
+	run := log.Runs[0]
+
+	if len(run.Results) != 3 {
+		t.Fatalf("expected 3 results (note without file reference dropped), got %d", len(run.Results))
+	}
+
+	tests := []struct {
+		rule  string
+		level string
+		file  string
+		start int
+		end   int
+	}{
+		{"security/the-threat-modeler", "error", "auth.go", 10, 12},
+		{"quality/kent-beck", "warning", "auth_test.go", 5, 5},
+		{"quality/unknown", "note", "main.go", 1, 1},
+	}
+	for i, tt := range tests {
+		r := run.Results[i]
+		loc := r.Locations[0].PhysicalLocation
+		if r.RuleID != tt.rule || r.Level != tt.level {
+			t.Errorf("result %d: rule %s level %s, want %s %s", i, r.RuleID, r.Level, tt.rule, tt.level)
+		}
+		if loc.ArtifactLocation.URI != tt.file || loc.Region.StartLine != tt.start {
+			t.Errorf("result %d: location %s:%d, want %s:%d", i, loc.ArtifactLocation.URI, loc.Region.StartLine, tt.file, tt.start)
+		}
+	}
+
+	if len(run.Results[0].Fixes) != 1 {
+		t.Errorf("expected a fix for the suggestion, got %d", len(run.Results[0].Fixes))
+	}
+	if run.Results[0].Properties["category"] != "security" {
+		t.Errorf("category = %v, want security", run.Results[0].Properties["category"])
+	}
+
+	if len(run.Tool.Driver.Rules) != 3 || run.Tool.Driver.Rules[0].ID != "quality/kent-beck" {
+		t.Errorf("rules = %+v, want 3 sorted by ID", run.Tool.Driver.Rules)
+	}
+	for key, want := range map[string]any{"pack": "go", "backend": "api/anthropic", "model": "claude-x", "blocking": true} {
+		if run.Properties[key] != want {
+			t.Errorf("run property %s = %v, want %v", key, run.Properties[key], want)
+		}
+	}
+	if !run.Invocations[0].ExecutionSuccessful {
+		t.Error("expected a successful invocation")
+	}
+}
+
+func TestFormatSARIFMergesClusters(t *testing.T) {
+	result := &SynthesizedResult{
+		Verdict: VerdictBlock,
+		Perspectives: []ExpertVerdict{
+			{Expert: "kent", Verdict: VerdictComment, Notes: []string{"main.go:2: Missing nil check"}},
+			{Expert: "security", Verdict: VerdictBlock, Notes: []string{"main.go:2: Nil dereference"}},
+			{Expert: "rob", Verdict: VerdictComment, Notes: []string{"main.go:2: Rename x"}},
+		},
+	}
+
+	results := FormatSARIF(result, nil, SARIFMeta{}).Runs[0].Results
+
+	if len(results) != 2 {
+		t.Fatalf("expected the two nil findings reported once, got %d results", len(results))
+	}
+	if results[0].RuleID != "quality/security" || results[0].Level != "error" {
+		t.Errorf("merged result: rule %s level %s, want the lead finding's", results[0].RuleID, results[0].Level)
+	}
+	if experts, _ := results[0].Properties["experts"].([]string); strings.Join(experts, ",") != "kent,security" {
+		t.Errorf("experts property = %v, want kent,security", results[0].Properties["experts"])
+	}
+}
+
+func TestFormatSARIFErrors(t *testing.T) {
+	result := &SynthesizedResult{
+		Verdict: VerdictComment,
+		Errors:  []string{"ada: timeout"},
+	}
+
+	log := FormatSARIF(result, nil, SARIFMeta{Backend: "cli"})
+	inv := log.Runs[0].Invocations[0]
+
+	if inv.ExecutionSuccessful {
+		t.Error("expected an unsuccessful invocation when every expert failed")
+	}
+	if len(inv.Notifications) != 1 || inv.Notifications[0].Message.Text != "ada: timeout" {
+		t.Errorf("notifications = %+v", inv.Notifications)
+	}
+
+	data, err := FormatSARIFJSON(log)
+	if err != nil {
+		t.Fatalf("FormatSARIFJSON: %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if decoded["$schema"] != SARIFSchema {
+		t.Errorf("$schema = %v", decoded["$schema"])
+	}
+	results, ok := decoded["runs"].([]any)[0].(map[string]any)["results"].([]any)
+	if !ok || len(results) != 0 {
+		t.Errorf("results should be an empty array, got %v", decoded["runs"])
+	}
+}
+
+func TestFormatSARIFSkippedFiles(t *testing.T) {
+	result := &SynthesizedResult{
+		Verdict:  VerdictPass,
+		Skipped:  []SkippedFile{{Path: "big.go", Reason: "budget exceeded"}},
+		Filtered: []SkippedFile{{Path: "vendor/x.go", Reason: "marked linguist-vendored in .gitattributes"}},
+	}
+
+	inv := FormatSARIF(result, nil, SARIFMeta{Backend: "cli"}).Runs[0].Invocations[0]
+
+	if !inv.ExecutionSuccessful {
+		t.Error("leaving files out doesn't make the review fail")
+	}
+	want := []struct{ level, text, uri string }{
+		{"warning", "Not reviewed: budget exceeded", "big.go"},
+		{"note", "Filtered out: marked linguist-vendored in .gitattributes", "vendor/x.go"},
+	}
+	if len(inv.Notifications) != len(want) {
+		t.Fatalf("notifications = %+v", inv.Notifications)
+	}
+	for i, w := range want {
+		n := inv.Notifications[i]
+		if n.Level != w.level || n.Message.Text != w.text || len(n.Locations) != 1 || n.Locations[0].PhysicalLocation.ArtifactLocation.URI != w.uri {
+			t.Errorf("notification %d = %+v, want %s %q on %s", i, n, w.level, w.text, w.uri)
+		}
+	}
+}
+
+func TestDomainString(t *testing.T) {
+	if DomainSecurity.String() != "security" || DomainQuality.String() != "quality" {
+		t.Errorf("got %s, %s", DomainSecurity, DomainQuality)
+	}
+}
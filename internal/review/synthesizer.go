@@ -8,8 +8,8 @@ import (
 )
 
 // Synthesize aggregates individual expert verdicts into a single result.
-// It resolves conflicts using the decision hierarchy, detects tensions
-// from expert metadata, and generates a summary.
+// It resolves the overall verdict (see ResolveOverallVerdict), detects
+// tensions from expert metadata, and generates a summary.
 func Synthesize(verdicts []ExpertVerdict, experts []*expert.Expert, errors []string) *SynthesizedResult {
 	result := &SynthesizedResult{
 		Verdict:      VerdictPass,
@@ -31,14 +31,15 @@ func Synthesize(verdicts []ExpertVerdict, experts []*expert.Expert, errors []str
 		byID[e.ID] = e
 	}
 
-	// Determine overall verdict: highest severity wins, weighted by hierarchy
-	result.Verdict = ResolveOverallVerdict(verdicts, byID)
+	// Determine overall verdict: highest severity wins
+	result.Verdict = ResolveOverallVerdict(verdicts)
 
 	// Determine blocking status
 	result.Blocking = ResolveBlocking(verdicts)
 
-	// Find agreements
-	result.Agreements = findAgreements(verdicts)
+	// Find agreements: findings several experts raised come first
+	result.Clusters = ClusterFindings(verdicts)
+	result.Agreements = append(clusterAgreements(result.Clusters), findAgreements(verdicts)...)
 
 	// Find tensions
 	result.Tension = findTension(verdicts, byID)
@@ -49,32 +50,20 @@ func Synthesize(verdicts []ExpertVerdict, experts []*expert.Expert, errors []str
 	return result
 }
 
-// ResolveOverallVerdict determines the aggregate verdict.
-// A block from a higher-priority domain outweighs a pass from lower ones.
-func ResolveOverallVerdict(verdicts []ExpertVerdict, experts map[string]*expert.Expert) Verdict {
+// ResolveOverallVerdict determines the aggregate verdict: the most severe
+// verdict of the experts that didn't fail, whatever their domain. Packs that
+// want domains to weigh in declare a Policy.
+func ResolveOverallVerdict(verdicts []ExpertVerdict) Verdict {
 	highest := VerdictPass
-	highestDomain := DomainQuality
 
 	for _, v := range verdicts {
 		if v.Error != "" {
 			continue // skip failed experts
 		}
 
-		domain := DomainQuality
-		if e, ok := experts[v.Expert]; ok {
-			domain = ExpertDomain(e)
-		}
-
 		// Higher severity always wins
 		if v.Verdict.Severity() > highest.Severity() {
 			highest = v.Verdict
-			highestDomain = domain
-			continue
-		}
-
-		// Same severity: higher domain takes precedence (for tie-breaking context)
-		if v.Verdict.Severity() == highest.Severity() && domain > highestDomain {
-			highestDomain = domain
 		}
 	}
 
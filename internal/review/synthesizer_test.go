@@ -132,14 +132,6 @@ func TestSynthesize(t *testing.T) {
 }
 
 func TestResolveOverallVerdict(t *testing.T) {
-	securityExpert := &expert.Expert{ID: "sec", Name: "Sec", Focus: "Application security"}
-	qualityExpert := &expert.Expert{ID: "qual", Name: "Qual", Focus: "Test-driven development"}
-
-	byID := map[string]*expert.Expert{
-		"sec":  securityExpert,
-		"qual": qualityExpert,
-	}
-
 	tests := []struct {
 		name     string
 		verdicts []ExpertVerdict
@@ -173,7 +165,7 @@ func TestResolveOverallVerdict(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := ResolveOverallVerdict(tt.verdicts, byID)
+			got := ResolveOverallVerdict(tt.verdicts)
 			if got != tt.want {
 				t.Errorf("ResolveOverallVerdict = %q, want %q", got, tt.want)
 			}
@@ -0,0 +1,237 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Usage counts the tokens of one or more model calls and their estimated
+// cost in US dollars. API backends read the counts from the provider's
+// response; CLI backends report none.
+type Usage struct {
+	InputTokens  int     `json:"input_tokens"`
+	OutputTokens int     `json:"output_tokens"`
+	Cost         float64 `json:"cost_usd"`            // estimated from Prices
+	Estimated    bool    `json:"estimated,omitempty"` // some counts are EstimateTokens guesses: the provider reported none
+	Unpriced     bool    `json:"unpriced,omitempty"`  // some calls used a model missing from Prices; Cost leaves them out
+}
+
+// Plus returns the sum of u and o. Either may be nil; the sum is nil when both are.
+func (u *Usage) Plus(o *Usage) *Usage {
+	switch {
+	case u == nil && o == nil:
+		return nil
+	case u == nil:
+		sum := *o
+		return &sum
+	case o == nil:
+		sum := *u
+		return &sum
+	}
+	return &Usage{
+		InputTokens:  u.InputTokens + o.InputTokens,
+		OutputTokens: u.OutputTokens + o.OutputTokens,
+		Cost:         u.Cost + o.Cost,
+		Estimated:    u.Estimated || o.Estimated,
+		Unpriced:     u.Unpriced || o.Unpriced,
+	}
+}
+
+// String describes the usage, e.g. "1204 in / 312 out tokens, est. $0.0083".
+func (u *Usage) String() string {
+	tokens := fmt.Sprintf("%d in / %d out tokens", u.InputTokens, u.OutputTokens)
+	if u.Estimated {
+		tokens = "~" + tokens
+	}
+	switch {
+	case u.Unpriced && u.Cost == 0:
+		return tokens + ", cost unknown"
+	case u.Unpriced:
+		return tokens + ", est. " + formatCost(u.Cost) + " plus unpriced calls"
+	default:
+		return tokens + ", est. " + formatCost(u.Cost)
+	}
+}
+
+// totalUsage sums the usage of verdicts, or returns nil when none has any.
+func totalUsage(verdicts []ExpertVerdict) *Usage {
+	var total *Usage
+	for _, v := range verdicts {
+		total = total.Plus(v.Usage)
+	}
+	return total
+}
+
+// formatCost renders a dollar amount, with cents precision from a dollar up.
+func formatCost(c float64) string {
+	if c >= 1 {
+		return fmt.Sprintf("$%.2f", c)
+	}
+	return fmt.Sprintf("$%.4f", c)
+}
+
+// Price is what a model charges, in US dollars per million tokens.
+type Price struct {
+	Input  float64
+	Output float64
+}
+
+// Cost returns the price of the given input and output token counts.
+func (p Price) Cost(input, output int) float64 {
+	return (float64(input)*p.Input + float64(output)*p.Output) / 1e6
+}
+
+// Prices lists the list prices of hosted models, keyed by model name. A
+// key also prices the model's dated snapshots ("claude-sonnet-4-5-20250929",
+// "gpt-4o-2024-08-06"), but no other variant: "gpt-5-pro" is not "gpt-5",
+// and models missing here are left unpriced rather than guessed at.
+// GitHub Models are priced as the model they serve ("openai/gpt-4o" as
+// "gpt-4o"), at its paid rates. Ollama runs locally and costs nothing.
+var Prices = map[string]Price{
+	"claude-opus-4-6":   {Input: 5, Output: 25},
+	"claude-opus-4-5":   {Input: 5, Output: 25},
+	"claude-opus-4-1":   {Input: 15, Output: 75},
+	"claude-opus-4-0":   {Input: 15, Output: 75},
+	"claude-opus-4":     {Input: 15, Output: 75},
+	"claude-sonnet-4-6": {Input: 3, Output: 15},
+	"claude-sonnet-4-5": {Input: 3, Output: 15},
+	"claude-sonnet-4-0": {Input: 3, Output: 15},
+	"claude-sonnet-4":   {Input: 3, Output: 15},
+	"claude-3-7-sonnet": {Input: 3, Output: 15},
+	"claude-haiku-4-5":  {Input: 1, Output: 5},
+	"claude-3-5-haiku":  {Input: 0.8, Output: 4},
+	"gpt-5":             {Input: 1.25, Output: 10},
+	"gpt-5-mini":        {Input: 0.25, Output: 2},
+	"gpt-5-nano":        {Input: 0.05, Output: 0.4},
+	"gpt-4.1":           {Input: 2, Output: 8},
+	"gpt-4.1-mini":      {Input: 0.4, Output: 1.6},
+	"gpt-4.1-nano":      {Input: 0.1, Output: 0.4},
+	"gpt-4o":            {Input: 2.5, Output: 10},
+	"gpt-4o-mini":       {Input: 0.15, Output: 0.6},
+	"o3":                {Input: 2, Output: 8},
+	"o3-mini":           {Input: 1.1, Output: 4.4},
+	"o4-mini":           {Input: 1.1, Output: 4.4},
+}
+
+// snapshotSuffix matches the date a model snapshot's name ends with:
+// anthropic's "-20250929" or openai's "-2024-08-06".
+var snapshotSuffix = regexp.MustCompile(`-(\d{8}|\d{4}-\d{2}-\d{2})$`)
+
+// PriceFor returns the price of a provider's model, and false when Prices
+// lists neither it nor, for a dated snapshot, the model it snapshots.
+func PriceFor(provider, model string) (Price, bool) {
+	if provider == "ollama" {
+		return Price{}, true
+	}
+	if i := strings.LastIndex(model, "/"); i >= 0 {
+		model = model[i+1:]
+	}
+
+	if p, ok := Prices[model]; ok {
+		return p, true
+	}
+	p, ok := Prices[snapshotSuffix.ReplaceAllString(model, "")]
+	return p, ok
+}
+
+// Unpriced names the backends b calls, every one of a fallback chain's
+// included, that a Budget can't cap because their calls can't be priced:
+// CLI backends, which report no usage, and API models PriceFor doesn't
+// know.
+func Unpriced(b Backend) []string {
+	switch b := b.(type) {
+	case *FallbackBackend:
+		var names []string
+		for _, fb := range b.Backends {
+			names = append(names, Unpriced(fb)...)
+		}
+		return names
+	case *APIBackend:
+		if _, ok := PriceFor(b.Provider, b.Model); ok {
+			return nil
+		}
+	}
+	return []string{BackendName(b)}
+}
+
+// ErrBudgetExceeded is returned for calls that a review's Budget refused.
+var ErrBudgetExceeded = errors.New("review budget exceeded")
+
+// Budget caps what a review may spend on priced API calls. Each call
+// reserves its worst case before it is sent — its prompt plus the most
+// output it may return — and is refused when that could take the review
+// over Max; once the call returns, the reservation gives way to the actual
+// cost. Calls to unpriced models and CLI backends aren't counted. It is
+// safe for concurrent use.
+type Budget struct {
+	Max float64 // US dollars
+
+	mu       sync.Mutex
+	spent    float64
+	reserved float64
+	exceeded bool
+}
+
+// NewBudget returns a budget of limit US dollars.
+func NewBudget(limit float64) *Budget {
+	return &Budget{Max: limit}
+}
+
+// Spent returns the cost of the calls made so far.
+func (b *Budget) Spent() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.spent
+}
+
+// Exceeded reports whether the budget has refused a call.
+func (b *Budget) Exceeded() bool {
+	if b == nil {
+		return false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.exceeded
+}
+
+// reserve claims cost for a call about to be sent.
+func (b *Budget) reserve(cost float64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.spent+b.reserved+cost > b.Max {
+		b.exceeded = true
+		return fmt.Errorf("%w: %s of %s spent, the next call could cost up to %s",
+			ErrBudgetExceeded, formatCost(b.spent), formatCost(b.Max), formatCost(cost))
+	}
+	b.reserved += cost
+	return nil
+}
+
+// settle releases a reservation and records what the call actually cost.
+func (b *Budget) settle(reserved, actual float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.reserved -= reserved
+	b.spent += actual
+}
+
+type budgetKey struct{}
+
+// withBudget attaches a budget to ctx, so the backends of a review —
+// per-expert, fallback and chair alike — draw on the same one.
+func withBudget(ctx context.Context, b *Budget) context.Context {
+	if b == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, budgetKey{}, b)
+}
+
+// budgetFrom returns the budget attached to ctx, or nil.
+func budgetFrom(ctx context.Context) *Budget {
+	b, _ := ctx.Value(budgetKey{}).(*Budget)
+	return b
+}
@@ -0,0 +1,371 @@
+package review
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+const usageVerdictJSON = `{"expert":"test-expert","verdict":"pass","confidence":0.9,"notes":["Looks good"],"blocking":false}`
+
+func TestAPIBackendUsage(t *testing.T) {
+	tests := []struct {
+		provider, model string
+		response        map[string]any
+		cost            float64
+	}{
+		{"anthropic", "claude-sonnet-4-6", map[string]any{
+			"content": []map[string]string{{"type": "text", "text": usageVerdictJSON}},
+			"usage":   map[string]int{"input_tokens": 1000, "output_tokens": 200},
+		}, 0.006},
+		{"openai", "gpt-4o", map[string]any{
+			"choices": []map[string]any{{"message": map[string]string{"content": usageVerdictJSON}}},
+			"usage":   map[string]int{"prompt_tokens": 1000, "completion_tokens": 200},
+		}, 0.0045},
+		{"github", "openai/gpt-4.1-mini", map[string]any{
+			"choices": []map[string]any{{"message": map[string]string{"content": usageVerdictJSON}}},
+			"usage":   map[string]int{"prompt_tokens": 1000, "completion_tokens": 200},
+		}, 0.00072},
+		{"ollama", "llama3", map[string]any{
+			"message":           map[string]string{"content": usageVerdictJSON},
+			"prompt_eval_count": 1000,
+			"eval_count":        200,
+		}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = json.NewEncoder(w).Encode(tt.response)
+			}))
+			defer server.Close()
+
+			backend, err := newAPIBackendWithClient(tt.provider, tt.model, server.Client())
+			if err != nil {
+				t.Fatal(err)
+			}
+			backend.SetBaseURL(server.URL)
+
+			verdict, err := backend.Review(context.Background(), testExpert(), testSubmission())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			u := verdict.Usage
+			if u == nil || u.InputTokens != 1000 || u.OutputTokens != 200 || u.Estimated || u.Unpriced {
+				t.Fatalf("usage = %+v, want 1000 in / 200 out as reported", u)
+			}
+			if diff := u.Cost - tt.cost; diff > 1e-9 || diff < -1e-9 {
+				t.Errorf("cost = %g, want %g", u.Cost, tt.cost)
+			}
+		})
+	}
+}
+
+func TestAPIBackendStreamUsage(t *testing.T) {
+	anthropic := func(w io.Writer) {
+		_, _ = io.WriteString(w, "data: {\"type\":\"message_start\",\"message\":{\"usage\":{\"input_tokens\":900,\"output_tokens\":1}}}\n\n")
+		ev, _ := json.Marshal(map[string]any{"type": "content_block_delta", "delta": map[string]string{"text": usageVerdictJSON}})
+		_, _ = io.WriteString(w, "data: "+string(ev)+"\n\n")
+		_, _ = io.WriteString(w, "data: {\"type\":\"message_delta\",\"usage\":{\"output_tokens\":150}}\n\n")
+		_, _ = io.WriteString(w, "data: {\"type\":\"message_stop\"}\n\n")
+	}
+	openai := func(w io.Writer) {
+		ev, _ := json.Marshal(map[string]any{"choices": []map[string]any{{"delta": map[string]string{"content": usageVerdictJSON}}}})
+		_, _ = io.WriteString(w, "data: "+string(ev)+"\n\n")
+		_, _ = io.WriteString(w, "data: {\"choices\":[],\"usage\":{\"prompt_tokens\":900,\"completion_tokens\":150}}\n\n")
+		_, _ = io.WriteString(w, "data: [DONE]\n\n")
+	}
+	ollama := func(w io.Writer) {
+		ev, _ := json.Marshal(map[string]any{"message": map[string]string{"content": usageVerdictJSON}, "done": false})
+		_, _ = w.Write(append(ev, '\n'))
+		_, _ = io.WriteString(w, `{"message":{"content":""},"done":true,"prompt_eval_count":900,"eval_count":150}`+"\n")
+	}
+
+	tests := []struct {
+		provider string
+		write    func(io.Writer)
+	}{
+		{"anthropic", anthropic},
+		{"openai", openai},
+		{"github", openai},
+		{"ollama", ollama},
+	}
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]any
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				_, asked := body["stream_options"]
+				if wantAsk := tt.provider == "openai" || tt.provider == "github"; asked != wantAsk {
+					t.Errorf("stream_options sent = %v, want %v", asked, wantAsk)
+				}
+				tt.write(w)
+			}))
+			defer server.Close()
+
+			backend, err := newAPIBackendWithClient(tt.provider, "gpt-4o", server.Client())
+			if err != nil {
+				t.Fatal(err)
+			}
+			backend.SetBaseURL(server.URL)
+			backend.Stream = true
+
+			verdict, err := backend.Review(context.Background(), testExpert(), testSubmission())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if u := verdict.Usage; u == nil || u.InputTokens != 900 || u.OutputTokens != 150 {
+				t.Errorf("usage = %+v, want 900 in / 150 out", u)
+			}
+		})
+	}
+}
+
+func TestAPIBackendUsage_Estimated(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{{"message": map[string]string{"content": usageVerdictJSON}}},
+		})
+	}))
+	defer server.Close()
+
+	backend, err := newAPIBackendWithClient("openai", "some-new-model", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend.SetBaseURL(server.URL)
+
+	verdict, err := backend.Review(context.Background(), testExpert(), testSubmission())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	u := verdict.Usage
+	if u == nil || !u.Estimated || !u.Unpriced || u.InputTokens == 0 || u.OutputTokens != EstimateTokens(usageVerdictJSON) {
+		t.Fatalf("usage = %+v, want an unpriced estimate", u)
+	}
+	if got := u.String(); !strings.HasPrefix(got, "~") || !strings.HasSuffix(got, "cost unknown") {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestAPIBackend_BudgetRefusesCall(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"content": []map[string]string{{"type": "text", "text": usageVerdictJSON}},
+			"usage":   map[string]int{"input_tokens": 1000, "output_tokens": 500},
+		})
+	}))
+	defer server.Close()
+
+	backend, err := newAPIBackendWithClient("anthropic", "claude-sonnet-4-6", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend.SetBaseURL(server.URL)
+
+	// Each call reserves ~$0.017, the prompt plus 1024 output tokens, and
+	// costs $0.0105: the second could take the review over $0.025.
+	budget := NewBudget(0.025)
+	runner := &Runner{Backend: backend, Budget: budget}
+	chunks := ChunkResult{Files: []FileDiff{
+		{Path: "a.go", Diff: "+a"},
+		{Path: "b.go", Diff: "+b"},
+		{Path: "c.go", Diff: "+c"},
+	}}
+
+	result := runner.RunPerFile(context.Background(), debateInputs("kent"), chunks, Submission{})
+
+	if requests.Load() != 1 {
+		t.Errorf("sent %d requests, want 1", requests.Load())
+	}
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0], "b.go: kent: ") || !strings.Contains(result.Errors[0], "review budget exceeded") {
+		t.Errorf("errors = %v, want b.go's call refused", result.Errors)
+	}
+	if !strings.HasPrefix(result.Summary, "2 files reviewed") || !strings.Contains(result.Summary, "Skipped files: c.go.") {
+		t.Errorf("summary = %q, want the review to stop before c.go", result.Summary)
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0].Path != "c.go" || result.Skipped[0].Reason != "budget exceeded" {
+		t.Errorf("skipped = %+v, want c.go left over budget", result.Skipped)
+	}
+	if spent := budget.Spent(); !budget.Exceeded() || spent < 0.0104 || spent > 0.0106 {
+		t.Errorf("spent %g (exceeded %v), want the first call's actual $0.0105", spent, budget.Exceeded())
+	}
+	if result.Usage == nil || result.Usage.InputTokens != 1000 {
+		t.Errorf("usage = %+v, want the call that was made", result.Usage)
+	}
+}
+
+func TestAPIBackend_BudgetCapsOutput(t *testing.T) {
+	tests := []struct {
+		provider, model, field string
+	}{
+		{"openai", "gpt-4o", "max_completion_tokens"},
+		{"github", "openai/gpt-4.1-mini", "max_tokens"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			var body map[string]any
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				data, _ := io.ReadAll(r.Body)
+				body = nil
+				_ = json.Unmarshal(data, &body)
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"choices": []map[string]any{{"message": map[string]string{"content": usageVerdictJSON}}},
+				})
+			}))
+			defer server.Close()
+
+			backend, err := newAPIBackendWithClient(tt.provider, tt.model, server.Client())
+			if err != nil {
+				t.Fatal(err)
+			}
+			backend.SetBaseURL(server.URL)
+
+			if _, err := backend.Review(context.Background(), testExpert(), testSubmission()); err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := body[tt.field]; ok {
+				t.Errorf("%s sent without a budget", tt.field)
+			}
+
+			ctx := withBudget(context.Background(), NewBudget(1))
+			if _, err := backend.Review(ctx, testExpert(), testSubmission()); err != nil {
+				t.Fatal(err)
+			}
+			if got, _ := body[tt.field].(float64); got != defaultMaxOutput {
+				t.Errorf("%s = %v, want the %d tokens reserved", tt.field, body[tt.field], defaultMaxOutput)
+			}
+		})
+	}
+}
+
+func TestBudget_Reservations(t *testing.T) {
+	b := NewBudget(1)
+
+	if err := b.reserve(0.6); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.reserve(0.6); !errors.Is(err, ErrBudgetExceeded) {
+		t.Errorf("a second reservation would overlap the first: %v", err)
+	}
+	b.settle(0.6, 0.1)
+	if err := b.reserve(0.6); err != nil {
+		t.Errorf("the first call cost less than reserved: %v", err)
+	}
+}
+
+func TestPriceFor(t *testing.T) {
+	tests := []struct {
+		provider, model string
+		want            Price
+		ok              bool
+	}{
+		{"anthropic", "claude-sonnet-4-5-20250929", Price{Input: 3, Output: 15}, true},
+		{"anthropic", "claude-opus-4-5", Price{Input: 5, Output: 25}, true},
+		{"anthropic", "claude-opus-4-6", Price{Input: 5, Output: 25}, true},
+		{"anthropic", "claude-opus-4-20250514", Price{Input: 15, Output: 75}, true},
+		{"openai", "gpt-4o-mini", Price{Input: 0.15, Output: 0.6}, true},
+		{"openai", "gpt-4o-2024-08-06", Price{Input: 2.5, Output: 10}, true},
+		{"github", "openai/gpt-4.1-mini", Price{Input: 0.4, Output: 1.6}, true},
+		{"ollama", "qwen2.5-coder", Price{}, true},
+		{"openai", "ft:custom", Price{}, false},
+		{"openai", "gpt-5-pro", Price{}, false},
+		{"openai", "o3-pro", Price{}, false},
+		{"openai", "gpt-4o-audio-preview", Price{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := PriceFor(tt.provider, tt.model)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("PriceFor(%s, %s) = %v, %v; want %v, %v", tt.provider, tt.model, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestUnpriced(t *testing.T) {
+	api := func(provider, model string) Backend {
+		b, err := NewAPIBackend(provider, model)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+	chain := NewFallbackBackend(
+		api("anthropic", "claude-sonnet-4-6"),
+		api("openai", "gpt-5-pro"),
+		api("ollama", "llama3"),
+		NewCLIBackend("claude", nil),
+	)
+
+	got := Unpriced(chain)
+	if len(got) != 2 || got[0] != "api:openai/gpt-5-pro" || !strings.HasPrefix(got[1], "cli") {
+		t.Errorf("Unpriced() = %v, want the unpriced model and the CLI backend", got)
+	}
+	if got := Unpriced(api("openai", "gpt-4o")); len(got) != 0 {
+		t.Errorf("Unpriced() = %v for a priced model", got)
+	}
+}
+
+func TestRunner_UsageTotals(t *testing.T) {
+	usage := &Usage{InputTokens: 100, OutputTokens: 10, Cost: 0.001}
+	backend := &chairBackend{
+		MockBackend: MockBackend{Results: map[string]ExpertVerdict{
+			"kent": {Expert: "kent", Verdict: VerdictPass, Usage: usage},
+			"rob":  {Expert: "rob", Verdict: VerdictPass, Usage: usage},
+		}},
+		reply: `{"verdict":"pass","agreements":[],"tension":"","summary":"Ship it."}`,
+	}
+	runner := &Runner{Backend: backend, Options: ReviewOptions{Rounds: 3, Chair: true}}
+
+	result := runner.Run(context.Background(), debateInputs("kent", "rob"), Submission{Content: "diff"})
+
+	// Two rounds before converging, so each expert made two calls.
+	for _, p := range result.Perspectives {
+		if p.Usage == nil || p.Usage.InputTokens != 200 {
+			t.Errorf("%s usage = %+v, want both rounds", p.Expert, p.Usage)
+		}
+	}
+	if result.Usage == nil || result.Usage.InputTokens != 400 || result.Usage.OutputTokens != 40 {
+		t.Errorf("total usage = %+v, want every expert call", result.Usage)
+	}
+}
+
+func TestFormat_Usage(t *testing.T) {
+	result := &SynthesizedResult{
+		Verdict: VerdictPass,
+		Perspectives: []ExpertVerdict{
+			{Expert: "kent", Verdict: VerdictPass, Usage: &Usage{InputTokens: 1204, OutputTokens: 312, Cost: 0.0083}},
+			{Expert: "rob", Verdict: VerdictPass},
+		},
+		Usage: &Usage{InputTokens: 1204, OutputTokens: 312, Cost: 0.0083, Unpriced: true},
+	}
+
+	human := FormatHuman(result, "", 2)
+	for _, want := range []string{
+		"  (usage: 1204 in / 312 out tokens, est. $0.0083)\n",
+		"Usage: 1204 in / 312 out tokens, est. $0.0083 plus unpriced calls\n",
+	} {
+		if !strings.Contains(human, want) {
+			t.Errorf("human output missing %q:\n%s", want, human)
+		}
+	}
+
+	body := formatReviewBody(result, "", 2)
+	for _, want := range []string{
+		"| Expert | Verdict | Key Concern | Usage |",
+		"| kent | pass | — | 1204 in / 312 out tokens, est. $0.0083 |",
+		"| rob | pass | — | — |",
+		"· 1204 in / 312 out tokens, est. $0.0083 plus unpriced calls</sub>",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("GitHub body missing %q:\n%s", want, body)
+		}
+	}
+}